@@ -1,6 +1,7 @@
 package goftx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -57,10 +58,14 @@ type Position struct {
 }
 
 func (a *Account) GetAccountInformation() (*AccountInformation, error) {
-	request, err := a.client.prepareRequest(Request{
+	return a.GetAccountInformationWithContext(context.Background())
+}
+
+func (a *Account) GetAccountInformationWithContext(ctx context.Context) (*AccountInformation, error) {
+	request, err := a.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiGetAccountInformation),
+		URL:    fmt.Sprintf("%s%s", a.client.apiUrl, apiGetAccountInformation),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -81,10 +86,14 @@ func (a *Account) GetAccountInformation() (*AccountInformation, error) {
 }
 
 func (a *Account) GetPositions() ([]Position, error) {
-	request, err := a.client.prepareRequest(Request{
+	return a.GetPositionsWithContext(context.Background())
+}
+
+func (a *Account) GetPositionsWithContext(ctx context.Context) ([]Position, error) {
+	request, err := a.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiGetPositions),
+		URL:    fmt.Sprintf("%s%s", a.client.apiUrl, apiGetPositions),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -105,6 +114,10 @@ func (a *Account) GetPositions() ([]Position, error) {
 }
 
 func (a *Account) ChangeAccountLeverage(leverage decimal.Decimal) error {
+	return a.ChangeAccountLeverageWithContext(context.Background(), leverage)
+}
+
+func (a *Account) ChangeAccountLeverageWithContext(ctx context.Context, leverage decimal.Decimal) error {
 	body, err := json.Marshal(struct {
 		Leverage decimal.Decimal `json:"leverage"`
 	}{Leverage: leverage})
@@ -112,10 +125,10 @@ func (a *Account) ChangeAccountLeverage(leverage decimal.Decimal) error {
 		return errors.WithStack(err)
 	}
 
-	request, err := a.client.prepareRequest(Request{
+	request, err := a.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiPostLeverage),
+		URL:    fmt.Sprintf("%s%s", a.client.apiUrl, apiPostLeverage),
 		Body:   body,
 	})
 	if err != nil {
@@ -131,10 +144,14 @@ func (a *Account) ChangeAccountLeverage(leverage decimal.Decimal) error {
 }
 
 func (a *Account) GetWalletBalances() ([]Balance, error) {
-	request, err := a.client.prepareRequest(Request{
+	return a.GetWalletBalancesWithContext(context.Background())
+}
+
+func (a *Account) GetWalletBalancesWithContext(ctx context.Context) ([]Balance, error) {
+	request, err := a.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiGetWalletBalances),
+		URL:    fmt.Sprintf("%s%s", a.client.apiUrl, apiGetWalletBalances),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
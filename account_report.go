@@ -0,0 +1,161 @@
+package goftx
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// AccountReport is the state of a single account, Name is empty for the main account.
+type AccountReport struct {
+	Name              string
+	Collateral        decimal.Decimal
+	FreeCollateral    decimal.Decimal
+	TotalAccountValue decimal.Decimal
+	Balances          []Balance
+	Positions         []Position
+}
+
+// ConsolidatedReport combines the main account and every subaccount.
+// Balances are summed by coin and NetPositions holds the net size by future of open positions.
+type ConsolidatedReport struct {
+	Accounts          []AccountReport
+	Collateral        decimal.Decimal
+	FreeCollateral    decimal.Decimal
+	TotalAccountValue decimal.Decimal
+	Balances          map[string]Balance
+	NetPositions      map[string]decimal.Decimal
+}
+
+// GetConsolidatedReport fetches the balances, account information and positions of the main account
+// and of every subaccount concurrently. The first failing request cancels the others.
+func (s *SubAccounts) GetConsolidatedReport(ctx context.Context) (*ConsolidatedReport, error) {
+	subAccounts, err := s.GetSubAccountsWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	names := []string{""}
+	for _, subAccount := range subAccounts {
+		names = append(names, subAccount.Nickname)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	accounts := make([]AccountReport, len(names))
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			account, err := s.accountReport(ctx, name)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = errors.Wrapf(err, "subaccount %q", name)
+					cancel()
+				})
+				return
+			}
+			accounts[i] = *account
+		}(i, name)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return consolidate(accounts), nil
+}
+
+func (s *SubAccounts) accountReport(ctx context.Context, name string) (*AccountReport, error) {
+	client := s.client.ForSubAccount(name)
+
+	var (
+		balances []Balance
+		err      error
+	)
+	if name == "" {
+		balances, err = client.Account.GetWalletBalancesWithContext(ctx)
+	} else {
+		balances, err = s.GetSubAccountBalancesWithContext(ctx, name)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	info, err := client.Account.GetAccountInformationWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	positions, err := client.Account.GetPositionsWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &AccountReport{
+		Name:              name,
+		Collateral:        info.Collateral,
+		FreeCollateral:    info.FreeCollateral,
+		TotalAccountValue: info.TotalAccountValue,
+		Balances:          balances,
+		Positions:         positions,
+	}, nil
+}
+
+func consolidate(accounts []AccountReport) *ConsolidatedReport {
+	report := &ConsolidatedReport{
+		Accounts:     accounts,
+		Balances:     make(map[string]Balance),
+		NetPositions: make(map[string]decimal.Decimal),
+	}
+
+	for _, account := range accounts {
+		report.Collateral = report.Collateral.Add(account.Collateral)
+		report.FreeCollateral = report.FreeCollateral.Add(account.FreeCollateral)
+		report.TotalAccountValue = report.TotalAccountValue.Add(account.TotalAccountValue)
+
+		for _, balance := range account.Balances {
+			total := report.Balances[balance.Coin]
+			total.Coin = balance.Coin
+			total.Free = total.Free.Add(balance.Free)
+			total.Total = total.Total.Add(balance.Total)
+			report.Balances[balance.Coin] = total
+		}
+
+		for _, position := range account.Positions {
+			if position.NetSize.IsZero() {
+				continue
+			}
+			report.NetPositions[position.Future] = report.NetPositions[position.Future].Add(position.NetSize)
+		}
+	}
+
+	for future, netSize := range report.NetPositions {
+		if netSize.IsZero() {
+			delete(report.NetPositions, future)
+		}
+	}
+
+	return report
+}
+
+// Coins returns the coins of Balances sorted by name.
+func (r *ConsolidatedReport) Coins() []string {
+	coins := make([]string, 0, len(r.Balances))
+	for coin := range r.Balances {
+		coins = append(coins, coin)
+	}
+	sort.Strings(coins)
+	return coins
+}
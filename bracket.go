@@ -0,0 +1,262 @@
+package goftx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const DefaultBracketPollInterval = time.Second
+
+type BracketState int
+
+const (
+	// BracketPending waits for the entry to fill.
+	BracketPending BracketState = iota
+	// BracketActive has take profit and stop loss orders covering the filled entry size.
+	BracketActive
+	BracketTakeProfit
+	BracketStopLoss
+	// BracketCancelled ended without a triggered exit, e.g. the entry was cancelled before any fill.
+	BracketCancelled
+)
+
+func (s BracketState) Done() bool {
+	return s == BracketTakeProfit || s == BracketStopLoss || s == BracketCancelled
+}
+
+// BracketOrderPayload is an entry order with its exits.
+// The exits are reduce only trigger orders, they execute as market orders unless an order price is set.
+type BracketOrderPayload struct {
+	Entry                PlaceOrderPayload
+	TakeProfitPrice      decimal.Decimal
+	TakeProfitOrderPrice *decimal.Decimal
+	StopLossPrice        decimal.Decimal
+	StopLossOrderPrice   *decimal.Decimal
+}
+
+// BracketOrder tracks an entry order and attaches take profit and stop loss orders sized to its fills.
+// Once one exit triggers the other one and the rest of the entry are cancelled, the exchange has no OCO orders
+// so this only happens while Run is running.
+type BracketOrder struct {
+	orders       *Orders
+	payload      BracketOrderPayload
+	pollInterval time.Duration
+
+	mu         sync.Mutex
+	state      BracketState
+	entry      *Order
+	takeProfit *TriggerOrder
+	stopLoss   *TriggerOrder
+}
+
+// PlaceBracketOrder places the entry order, Run attaches the exits.
+func (o *Orders) PlaceBracketOrder(ctx context.Context, payload *BracketOrderPayload) (*BracketOrder, error) {
+	if !payload.TakeProfitPrice.IsPositive() || !payload.StopLossPrice.IsPositive() {
+		return nil, errors.New("take profit and stop loss prices are required")
+	}
+
+	entry, err := o.PlaceOrderWithContext(ctx, &payload.Entry)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &BracketOrder{
+		orders:       o,
+		payload:      *payload,
+		pollInterval: DefaultBracketPollInterval,
+		entry:        entry,
+	}, nil
+}
+
+func (b *BracketOrder) SetPollInterval(interval time.Duration) {
+	b.pollInterval = interval
+}
+
+func (b *BracketOrder) State() BracketState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *BracketOrder) Entry() Order {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return *b.entry
+}
+
+// TakeProfit returns nil until the entry has filled.
+func (b *BracketOrder) TakeProfit() *TriggerOrder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.takeProfit
+}
+
+// StopLoss returns nil until the entry has filled.
+func (b *BracketOrder) StopLoss() *TriggerOrder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stopLoss
+}
+
+// Run polls the entry and the exits until the bracket is done or ctx is done.
+// Open orders are left in place when ctx is done, use Cancel to remove them.
+func (b *BracketOrder) Run(ctx context.Context) (BracketState, error) {
+	for {
+		state, err := b.Update(ctx)
+		if err != nil || state.Done() {
+			return state, errors.WithStack(err)
+		}
+
+		select {
+		case <-time.After(b.pollInterval):
+		case <-ctx.Done():
+			return state, errors.WithStack(ctx.Err())
+		}
+	}
+}
+
+// Update runs a single poll: it handles triggered exits first and then resizes the exits to the filled entry size.
+func (b *BracketOrder) Update(ctx context.Context) (BracketState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state.Done() {
+		return b.state, nil
+	}
+
+	entry, err := b.orders.GetOrderWithContext(ctx, b.entry.ID)
+	if err != nil {
+		return b.state, errors.WithStack(err)
+	}
+	b.entry = entry
+
+	triggered, err := b.exitTriggered(ctx, b.takeProfit)
+	if err != nil {
+		return b.state, errors.WithStack(err)
+	}
+	if triggered {
+		return b.finish(ctx, BracketTakeProfit, b.stopLoss)
+	}
+
+	triggered, err = b.exitTriggered(ctx, b.stopLoss)
+	if err != nil {
+		return b.state, errors.WithStack(err)
+	}
+	if triggered {
+		return b.finish(ctx, BracketStopLoss, b.takeProfit)
+	}
+
+	if b.entry.FilledSize.IsZero() {
+		if b.entry.Status == OrderStatusClosed {
+			b.state = BracketCancelled
+		}
+		return b.state, nil
+	}
+
+	b.takeProfit, err = b.attachExit(ctx, b.takeProfit, TriggerTypeTakeProfit, b.payload.TakeProfitPrice, b.payload.TakeProfitOrderPrice)
+	if err != nil {
+		return b.state, errors.WithStack(err)
+	}
+	b.stopLoss, err = b.attachExit(ctx, b.stopLoss, TriggerTypeStop, b.payload.StopLossPrice, b.payload.StopLossOrderPrice)
+	if err != nil {
+		return b.state, errors.WithStack(err)
+	}
+	b.state = BracketActive
+
+	return b.state, nil
+}
+
+// Cancel cancels the rest of the entry and both exits.
+func (b *BracketOrder) Cancel(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, err := b.finish(ctx, BracketCancelled, b.takeProfit, b.stopLoss)
+	return errors.WithStack(err)
+}
+
+// attachExit places the exit or resizes it to the filled entry size.
+func (b *BracketOrder) attachExit(ctx context.Context, exit *TriggerOrder, triggerType string, triggerPrice decimal.Decimal, orderPrice *decimal.Decimal) (*TriggerOrder, error) {
+	size := b.entry.FilledSize
+	if exit == nil {
+		side := SideSell
+		if b.entry.Side == SideSell {
+			side = SideBuy
+		}
+
+		placed, err := b.orders.PlaceTriggerOrderWithContext(ctx, &PlaceTriggerOrderPayload{
+			Market:       b.entry.Market,
+			Side:         side,
+			Size:         size,
+			Type:         triggerType,
+			ReduceOnly:   true,
+			TriggerPrice: &triggerPrice,
+			OrderPrice:   orderPrice,
+		})
+		return placed, errors.WithStack(err)
+	}
+
+	if exit.Size.Equal(size) {
+		return exit, nil
+	}
+
+	// Modifying a trigger order replaces it, the returned order has a new id.
+	modified, err := b.orders.ModifyTriggerOrderWithContext(ctx, &ModifyTriggerOrderPayload{
+		Size:         size,
+		TriggerPrice: triggerPrice,
+		OrderPrice:   orderPrice,
+	}, exit.ID)
+	if err != nil {
+		return exit, errors.WithStack(err)
+	}
+	return modified, nil
+}
+
+func (b *BracketOrder) exitTriggered(ctx context.Context, exit *TriggerOrder) (bool, error) {
+	if exit == nil {
+		return false, nil
+	}
+
+	startTime := int(exit.CreatedAt.Unix()) - 1
+	orders, err := b.orders.GetTriggerOrdersHistoryWithContext(ctx, &GetTriggerOrdersHistoryParams{
+		Market:    &exit.Market,
+		StartTime: &startTime,
+	})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	for _, order := range orders {
+		if order.ID == exit.ID {
+			return order.Status == TriggerStatusTriggered, nil
+		}
+	}
+	return false, nil
+}
+
+// finish cancels the open exits and the rest of the entry.
+func (b *BracketOrder) finish(ctx context.Context, state BracketState, exits ...*TriggerOrder) (BracketState, error) {
+	for _, exit := range exits {
+		if exit == nil {
+			continue
+		}
+		err := b.orders.CancelOpenTriggerOrderWithContext(ctx, exit.ID)
+		if err != nil && !IsOrderClosed(err) && !IsOrderNotFound(err) {
+			return b.state, errors.WithStack(err)
+		}
+	}
+
+	if b.entry.Status != OrderStatusClosed {
+		err := b.orders.CancelOrderWithContext(ctx, b.entry.ID)
+		if err != nil && !IsOrderClosed(err) && !IsOrderNotFound(err) {
+			return b.state, errors.WithStack(err)
+		}
+	}
+
+	b.state = state
+	return b.state, nil
+}
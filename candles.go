@@ -0,0 +1,288 @@
+package goftx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const maxCandlesPerRequest = 1500
+
+// CandleGap is a run of missing bars, StartTime and EndTime are the first and the last missing bar.
+type CandleGap struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// CandleDownloader fetches any date range of candles by splitting it into requests
+// of at most maxCandlesPerRequest bars.
+type CandleDownloader struct {
+	resolution Resolution
+	fetch      func(ctx context.Context, startTime, endTime int, limit int) ([]HistoricalPrice, error)
+}
+
+func (m *Markets) NewCandleDownloader(marketName string, resolution Resolution) *CandleDownloader {
+	return &CandleDownloader{
+		resolution: resolution,
+		fetch: func(ctx context.Context, startTime, endTime int, limit int) ([]HistoricalPrice, error) {
+			return m.GetHistoricalPricesWithContext(ctx, marketName, &GetHistoricalPricesParams{
+				Resolution: resolution,
+				Limit:      &limit,
+				StartTime:  &startTime,
+				EndTime:    &endTime,
+			})
+		},
+	}
+}
+
+// NewIndexCandleDownloader downloads index candles, they are returned as HistoricalPrice bars.
+func (f *Futures) NewIndexCandleDownloader(indexName string, resolution Resolution) *CandleDownloader {
+	return &CandleDownloader{
+		resolution: resolution,
+		fetch: func(ctx context.Context, startTime, endTime int, limit int) ([]HistoricalPrice, error) {
+			candles, err := f.GetHistoricalIndexWithContext(ctx, indexName, &GetHistoricalIndexParams{
+				IndexName:  indexName,
+				Resolution: int(resolution),
+				Limit:      &limit,
+				StartTime:  &startTime,
+				EndTime:    &endTime,
+			})
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			result := make([]HistoricalPrice, 0, len(candles))
+			for _, candle := range candles {
+				result = append(result, HistoricalPrice{
+					StartTime: candle.StartTime,
+					Open:      candle.Open,
+					Close:     candle.Close,
+					High:      candle.High,
+					Low:       candle.Low,
+					Volume:    candle.Volume,
+				})
+			}
+			return result, nil
+		},
+	}
+}
+
+// Download returns the sorted, de-duplicated bars between startTime and endTime
+// along with the gaps where the exchange returned no bar.
+func (d *CandleDownloader) Download(ctx context.Context, startTime, endTime time.Time) ([]HistoricalPrice, []CandleGap, error) {
+	var result []HistoricalPrice
+	err := d.each(ctx, startTime, endTime, func(candles []HistoricalPrice) error {
+		result = append(result, candles...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return result, FindCandleGaps(result, d.resolution, startTime, endTime), nil
+}
+
+// DownloadToFile stores the bars between startTime and endTime in path as JSON lines.
+// Bars already present in the file are kept, the download fills the range before the first
+// of them and resumes after the last of them. Every chunk is written as soon as it is fetched
+// and the incomplete last line of an interrupted write is dropped, so a download can be resumed.
+// The returned gaps cover the whole file content within the range.
+func (d *CandleDownloader) DownloadToFile(ctx context.Context, path string, startTime, endTime time.Time) ([]CandleGap, error) {
+	err := trimPartialLine(path)
+	if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return nil, errors.WithStack(err)
+	}
+
+	existing, err := ReadCandlesFile(path)
+	if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return nil, errors.WithStack(err)
+	}
+
+	ranges := [][2]time.Time{{startTime, endTime}}
+	if len(existing) > 0 {
+		first, last := existing[0].StartTime, existing[len(existing)-1].StartTime
+
+		resumeTime := last.Add(d.step())
+		if resumeTime.Before(startTime) {
+			resumeTime = startTime
+		}
+		ranges = [][2]time.Time{{resumeTime, endTime}}
+
+		if first.After(startTime) {
+			leadingEnd := first.Add(-d.step())
+			if leadingEnd.After(endTime) {
+				leadingEnd = endTime
+			}
+			ranges = append(ranges, [2]time.Time{startTime, leadingEnd})
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	candles := existing
+	for _, r := range ranges {
+		if r[0].After(r[1]) {
+			continue
+		}
+
+		err = d.each(ctx, r[0], r[1], func(chunk []HistoricalPrice) error {
+			for _, candle := range chunk {
+				err := encoder.Encode(candle)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+			}
+			candles = append(candles, chunk...)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].StartTime.Before(candles[j].StartTime)
+	})
+
+	return FindCandleGaps(candles, d.resolution, startTime, endTime), nil
+}
+
+// each calls handle with consecutive chunks of new bars in ascending order.
+func (d *CandleDownloader) each(ctx context.Context, startTime, endTime time.Time, handle func([]HistoricalPrice) error) error {
+	step := d.step()
+	if step <= 0 {
+		return errors.Errorf("invalid resolution: %d", d.resolution)
+	}
+	window := step * (maxCandlesPerRequest - 1)
+
+	var last time.Time
+	for chunkStart := startTime; !chunkStart.After(endTime); chunkStart = chunkStart.Add(window + step) {
+		chunkEnd := chunkStart.Add(window)
+		if chunkEnd.After(endTime) {
+			chunkEnd = endTime
+		}
+
+		candles, err := d.fetch(ctx, int(chunkStart.Unix()), int(chunkEnd.Unix()), maxCandlesPerRequest)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		sort.Slice(candles, func(i, j int) bool {
+			return candles[i].StartTime.Before(candles[j].StartTime)
+		})
+
+		chunk := make([]HistoricalPrice, 0, len(candles))
+		for _, candle := range candles {
+			if !candle.StartTime.After(last) || candle.StartTime.Before(startTime) || candle.StartTime.After(endTime) {
+				continue
+			}
+			chunk = append(chunk, candle)
+			last = candle.StartTime
+		}
+		if len(chunk) == 0 {
+			continue
+		}
+
+		err = handle(chunk)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// trimPartialLine cuts the unterminated last line of path, what is left are complete bars.
+func trimPartialLine(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return nil
+	}
+
+	return errors.WithStack(os.Truncate(path, int64(bytes.LastIndexByte(data, '\n')+1)))
+}
+
+func (d *CandleDownloader) step() time.Duration {
+	return time.Duration(d.resolution) * time.Second
+}
+
+// ReadCandlesFile reads bars written by CandleDownloader.DownloadToFile.
+// An incomplete last line left behind by an interrupted write is skipped.
+func ReadCandlesFile(path string) ([]HistoricalPrice, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []HistoricalPrice
+	lines := bytes.Split(data, []byte{'\n'})
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var candle HistoricalPrice
+		err = json.Unmarshal(line, &candle)
+		if err != nil {
+			if i == len(lines)-1 {
+				break
+			}
+			return nil, errors.WithStack(err)
+		}
+		result = append(result, candle)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+
+	return result, nil
+}
+
+// FindCandleGaps returns the bars of resolution between startTime and endTime missing from candles,
+// which must be sorted by StartTime.
+func FindCandleGaps(candles []HistoricalPrice, resolution Resolution, startTime, endTime time.Time) []CandleGap {
+	step := time.Duration(resolution) * time.Second
+	if step <= 0 {
+		return nil
+	}
+
+	expected := startTime.Truncate(step)
+	if expected.Before(startTime) {
+		expected = expected.Add(step)
+	}
+
+	var gaps []CandleGap
+	for _, candle := range candles {
+		if candle.StartTime.Before(expected) {
+			continue
+		}
+		if candle.StartTime.After(endTime) {
+			break
+		}
+		if candle.StartTime.After(expected) {
+			gaps = append(gaps, CandleGap{StartTime: expected, EndTime: candle.StartTime.Add(-step)})
+		}
+		expected = candle.StartTime.Add(step)
+	}
+
+	if !expected.After(endTime) {
+		gaps = append(gaps, CandleGap{StartTime: expected, EndTime: endTime.Truncate(step)})
+	}
+
+	return gaps
+}
@@ -0,0 +1,135 @@
+package goftx
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// hourlyDownloader serves a bar for every hour and records the requested ranges.
+func hourlyDownloader(requests *[][2]int) *CandleDownloader {
+	return &CandleDownloader{
+		resolution: 3600,
+		fetch: func(ctx context.Context, startTime, endTime int, limit int) ([]HistoricalPrice, error) {
+			*requests = append(*requests, [2]int{startTime, endTime})
+			var result []HistoricalPrice
+			for t := startTime; t <= endTime; t += 3600 {
+				result = append(result, HistoricalPrice{StartTime: time.Unix(int64(t), 0).UTC(), Close: decimal.NewFromInt(int64(t))})
+			}
+			return result, nil
+		},
+	}
+}
+
+func candlesFile(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "goftx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "candles.jsonl")
+}
+
+func TestDownloadToFileFillsLeadingRange(t *testing.T) {
+	path := candlesFile(t)
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(9 * time.Hour)
+
+	var requests [][2]int
+	downloader := hourlyDownloader(&requests)
+	_, err := downloader.DownloadToFile(context.Background(), path, start.Add(5*time.Hour), end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests = nil
+	gaps, err := downloader.DownloadToFile(context.Background(), path, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gaps) != 0 {
+		t.Errorf("gaps = %+v, want none", gaps)
+	}
+	want := [][2]int{{int(start.Unix()), int(start.Add(4 * time.Hour).Unix())}}
+	if len(requests) != 1 || requests[0] != want[0] {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+
+	candles, err := ReadCandlesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 10 || !candles[0].StartTime.Equal(start) || !candles[9].StartTime.Equal(end) {
+		t.Errorf("file holds %d bars from %v to %v", len(candles), candles[0].StartTime, candles[len(candles)-1].StartTime)
+	}
+}
+
+func TestDownloadToFileResumesAfterPartialLine(t *testing.T) {
+	path := candlesFile(t)
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(5 * time.Hour)
+
+	var requests [][2]int
+	downloader := hourlyDownloader(&requests)
+	_, err := downloader.DownloadToFile(context.Background(), path, start, start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An interrupted write leaves the beginning of the next bar behind.
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = file.WriteString(`{"startTime":"2021-01-01T03:00:00Z","op`)
+	file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candles, err := ReadCandlesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 3 {
+		t.Fatalf("read %d bars, want 3", len(candles))
+	}
+
+	requests = nil
+	gaps, err := downloader.DownloadToFile(context.Background(), path, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gaps) != 0 {
+		t.Errorf("gaps = %+v, want none", gaps)
+	}
+	if len(requests) != 1 || requests[0][0] != int(start.Add(3*time.Hour).Unix()) {
+		t.Errorf("requests = %v, want a single one from the fourth bar", requests)
+	}
+
+	candles, err = ReadCandlesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 6 {
+		t.Errorf("read %d bars, want 6", len(candles))
+	}
+}
+
+func TestReadCandlesFileRejectsCorruptLine(t *testing.T) {
+	path := candlesFile(t)
+	data := "{\"startTime\":\"2021-01-01T00:00:00Z\"}\nnot json\n{\"startTime\":\"2021-01-01T02:00:00Z\"}\n"
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadCandlesFile(path); err == nil {
+		t.Error("corrupt line in the middle of the file was accepted")
+	}
+}
@@ -2,6 +2,7 @@ package goftx
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -11,23 +12,75 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 )
 
 const (
-	apiUrl    = "https://ftx.com/api"
-	apiOtcUrl = "https://otc.ftx.com/api"
+	keyHeader        = "KEY"
+	signHeader       = "SIGN"
+	tsHeader         = "TS"
+	subAccountHeader = "SUBACCOUNT"
+)
+
+// HostProfile groups the endpoints and the auth header prefix of an exchange deployment.
+type HostProfile struct {
+	APIURL       string
+	OTCURL       string
+	WSURL        string
+	HeaderPrefix string
+}
 
-	keyHeader        = "FTX-KEY"
-	signHeader       = "FTX-SIGN"
-	tsHeader         = "FTX-TS"
-	subAccountHeader = "FTX-SUBACCOUNT"
+var (
+	HostFTX = HostProfile{
+		APIURL:       "https://ftx.com/api",
+		OTCURL:       "https://otc.ftx.com/api",
+		WSURL:        "wss://ftx.com/ws/",
+		HeaderPrefix: "FTX",
+	}
+	HostFTXUS = HostProfile{
+		APIURL:       "https://ftx.us/api",
+		OTCURL:       "https://ftx.us/api",
+		WSURL:        "wss://ftx.us/ws/",
+		HeaderPrefix: "FTXUS",
+	}
 )
 
 type Option func(c *Client)
 
+// WithHostProfile points every REST, OTC and websocket call at the hosts of profile.
+func WithHostProfile(profile HostProfile) Option {
+	return func(c *Client) {
+		c.apiUrl = strings.TrimSuffix(profile.APIURL, "/")
+		c.apiOtcUrl = strings.TrimSuffix(profile.OTCURL, "/")
+		c.wsUrl = profile.WSURL
+		c.headerPrefix = profile.HeaderPrefix
+	}
+}
+
+// WithBaseURL overrides the REST base URL, e.g. to use a local mock server or a proxy.
+func WithBaseURL(apiUrl string) Option {
+	return func(c *Client) {
+		c.apiUrl = strings.TrimSuffix(apiUrl, "/")
+	}
+}
+
+func WithOTCBaseURL(apiOtcUrl string) Option {
+	return func(c *Client) {
+		c.apiOtcUrl = strings.TrimSuffix(apiOtcUrl, "/")
+	}
+}
+
+func WithWebSocketURL(wsUrl string) Option {
+	return func(c *Client) {
+		c.wsUrl = wsUrl
+	}
+}
+
 func WithHTTPClient(client *http.Client) Option {
 	return func(c *Client) {
 		c.client = client
@@ -46,10 +99,19 @@ func WithAuth(key, secret string, subAccount ...string) Option {
 
 type Client struct {
 	client         *http.Client
+	apiUrl         string
+	apiOtcUrl      string
+	wsUrl          string
+	headerPrefix   string
 	apiKey         string
 	secret         string
 	subAccount     string
-	serverTimeDiff time.Duration
+	serverTimeDiff *int64
+	rateLimiter    RateLimiter
+	retryPolicy    *RetryPolicy
+	paperTrader    *PaperTrader
+	normalizer     *orderNormalizer
+	marketRegistry *MarketRegistry
 	SubAccounts
 	Markets
 	Account
@@ -58,38 +120,82 @@ type Client struct {
 	Converts
 	Futures
 	SpotMargin
+	Wallet
+	LeveragedTokens
+	Options
+	Staking
+	Stream
 }
 
 func New(opts ...Option) *Client {
 	client := &Client{
-		client: http.DefaultClient,
+		client:         http.DefaultClient,
+		serverTimeDiff: new(int64),
+		rateLimiter:    NewTokenBucketLimiter(DefaultGeneralRateLimit, DefaultOrderRateLimit, false),
 	}
+	WithHostProfile(HostFTX)(client)
 
 	for _, opt := range opts {
 		opt(client)
 	}
 
-	client.SubAccounts = SubAccounts{client: client}
-	client.Markets = Markets{client: client}
-	client.Account = Account{client: client}
-	client.Orders = Orders{client: client}
-	client.Fills = Fills{client: client}
-	client.Converts = Converts{client: client}
-	client.Futures = Futures{client: client}
-	client.SpotMargin = SpotMargin{client: client}
+	if client.marketRegistry == nil {
+		client.marketRegistry = NewMarketRegistry(client, DefaultMarketRegistryTTL)
+	}
+
+	client.Stream = Stream{
+		dialer:                 websocket.DefaultDialer,
+		wsReconnectionCount:    wsReconnectionCount,
+		wsReconnectionInterval: wsReconnectionInterval,
+	}
+	client.initServices()
 
 	return client
 }
 
+// ForSubAccount returns a view of the client authenticated against subAccount, an empty name is the main account.
+// The view shares the http.Client, the rate limiter, the retry policy, the market registry and the server time offset.
+func (c *Client) ForSubAccount(subAccount string) *Client {
+	view := *c
+	view.subAccount = url.PathEscape(subAccount)
+	view.initServices()
+	return &view
+}
+
+func (c *Client) initServices() {
+	c.SubAccounts = SubAccounts{client: c}
+	c.Markets = Markets{client: c}
+	c.Account = Account{client: c}
+	c.Orders = Orders{client: c}
+	c.Fills = Fills{client: c}
+	c.Converts = Converts{client: c}
+	c.Futures = Futures{client: c}
+	c.SpotMargin = SpotMargin{client: c}
+	c.Wallet = Wallet{client: c}
+	c.LeveragedTokens = LeveragedTokens{client: c}
+	c.Options = Options{client: c}
+	c.Staking = Staking{client: c}
+	c.Stream.client = c
+}
+
 func (c *Client) SetServerTimeDiff() error {
-	serverTime, err := c.GetServerTime()
+	return c.SetServerTimeDiffWithContext(context.Background())
+}
+
+func (c *Client) SetServerTimeDiffWithContext(ctx context.Context) error {
+	serverTime, err := c.GetServerTimeWithContext(ctx)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	c.serverTimeDiff = serverTime.Sub(time.Now().UTC())
+	atomic.StoreInt64(c.serverTimeDiff, int64(serverTime.Sub(time.Now().UTC())))
 	return nil
 }
 
+// serverTime is the local time corrected by the offset measured by SetServerTimeDiff.
+func (c *Client) serverTime() time.Time {
+	return time.Now().UTC().Add(time.Duration(atomic.LoadInt64(c.serverTimeDiff)))
+}
+
 type Response struct {
 	Success bool            `json:"success"`
 	Result  json.RawMessage `json:"result"`
@@ -105,8 +211,8 @@ type Request struct {
 	Body    []byte
 }
 
-func (c *Client) prepareRequest(request Request) (*http.Request, error) {
-	req, err := http.NewRequest(request.Method, request.URL, bytes.NewBuffer(request.Body))
+func (c *Client) prepareRequest(ctx context.Context, request Request) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.URL, bytes.NewBuffer(request.Body))
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -118,23 +224,7 @@ func (c *Client) prepareRequest(request Request) (*http.Request, error) {
 	req.URL.RawQuery = query.Encode()
 
 	if request.Auth {
-		nonce := strconv.FormatInt(time.Now().UTC().Add(c.serverTimeDiff).Unix()*1000, 10)
-		payload := nonce + req.Method + req.URL.Path
-		if req.URL.RawQuery != "" {
-			payload += "?" + req.URL.RawQuery
-		}
-		if len(request.Body) > 0 {
-			payload += string(request.Body)
-		}
-
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set(keyHeader, c.apiKey)
-		req.Header.Set(signHeader, c.getSignature(payload))
-		req.Header.Set(tsHeader, nonce)
-
-		if c.subAccount != "" {
-			req.Header.Set(subAccountHeader, c.subAccount)
-		}
+		c.signRequest(req, request.Body)
 	}
 
 	for k, v := range request.Headers {
@@ -144,7 +234,58 @@ func (c *Client) prepareRequest(request Request) (*http.Request, error) {
 	return req, nil
 }
 
+// signRequest sets the auth headers with a fresh nonce, it is called again when a request is retried.
+func (c *Client) signRequest(req *http.Request, body []byte) {
+	nonce := strconv.FormatInt(c.serverTime().Unix()*1000, 10)
+	payload := nonce + req.Method + req.URL.Path
+	if req.URL.RawQuery != "" {
+		payload += "?" + req.URL.RawQuery
+	}
+	if len(body) > 0 {
+		payload += string(body)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(c.header(keyHeader), c.apiKey)
+	req.Header.Set(c.header(signHeader), c.getSignature(payload))
+	req.Header.Set(c.header(tsHeader), nonce)
+
+	if c.subAccount != "" {
+		req.Header.Set(c.header(subAccountHeader), c.subAccount)
+	}
+}
+
 func (c *Client) do(req *http.Request) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := c.send(req)
+		retry := err != nil && c.retryPolicy.shouldRetry(req, err, attempt)
+		c.retryPolicy.observe(req, err, attempt, !retry)
+		if !retry {
+			return result, err
+		}
+
+		req, err = c.retry(req, err, attempt)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+}
+
+func (c *Client) send(req *http.Request) ([]byte, error) {
+	if c.paperTrader != nil {
+		result, handled, err := c.paperTrader.serve(c, req)
+		if handled {
+			return result, err
+		}
+	}
+
+	if c.rateLimiter != nil {
+		err := c.rateLimiter.Wait(req.Context(), c.subAccount, requestClass(req))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
 	resp, err := c.client.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
@@ -161,16 +302,33 @@ func (c *Client) do(req *http.Request) ([]byte, error) {
 	var response Response
 	err = json.Unmarshal(res, &response)
 	if err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return nil, errors.WithStack(&APIError{
+				StatusCode: resp.StatusCode,
+				Message:    http.StatusText(resp.StatusCode),
+				Method:     req.Method,
+				Endpoint:   req.URL.Path,
+			})
+		}
 		return nil, errors.WithStack(err)
 	}
 
 	if !response.Success {
-		return nil, errors.Errorf("Status Code: %d	Error: %v", resp.StatusCode, response.Error)
+		return nil, errors.WithStack(&APIError{
+			StatusCode: resp.StatusCode,
+			Message:    response.Error,
+			Method:     req.Method,
+			Endpoint:   req.URL.Path,
+		})
 	}
 
 	return response.Result, nil
 }
 
+func (c *Client) header(name string) string {
+	return c.headerPrefix + "-" + name
+}
+
 func (c *Client) getSignature(payload string) string {
 	mac := hmac.New(sha256.New, []byte(c.secret))
 	mac.Write([]byte(payload))
@@ -178,9 +336,13 @@ func (c *Client) getSignature(payload string) string {
 }
 
 func (c *Client) GetServerTime() (*time.Time, error) {
-	request, err := c.prepareRequest(Request{
+	return c.GetServerTimeWithContext(context.Background())
+}
+
+func (c *Client) GetServerTimeWithContext(ctx context.Context) (*time.Time, error) {
+	request, err := c.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s/time", apiOtcUrl),
+		URL:    fmt.Sprintf("%s/time", c.apiOtcUrl),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
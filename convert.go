@@ -0,0 +1,167 @@
+package goftx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultConvertPollInterval = 250 * time.Millisecond
+	DefaultConvertExpiryMargin = time.Second
+)
+
+var (
+	ErrQuoteRejected = errors.New("quote price outside limits")
+	ErrQuoteExpired  = errors.New("quote expired")
+)
+
+// ConvertParams configure Converts.Convert.
+// LimitPrice is the worst accepted price of the base coin in the quote coin and MaxSlippage the worst
+// accepted deviation from the market mid price as a fraction, e.g. 0.005 for 0.5%; nil disables a check.
+// A quote is re-requested up to MaxRequotes times when it is rejected or about to expire.
+type ConvertParams struct {
+	FromCoin     string
+	ToCoin       string
+	Size         decimal.Decimal
+	LimitPrice   *decimal.Decimal
+	MaxSlippage  *decimal.Decimal
+	MaxRequotes  int
+	PollInterval time.Duration
+	ExpiryMargin time.Duration
+}
+
+// Convert requests a quote, waits until it is priced, checks the price and accepts it before it expires.
+// It returns the filled quote with its cost and proceeds.
+func (c *Converts) Convert(ctx context.Context, params *ConvertParams) (*QuoteStatus, error) {
+	pollInterval := params.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultConvertPollInterval
+	}
+	expiryMargin := params.ExpiryMargin
+	if expiryMargin <= 0 {
+		expiryMargin = DefaultConvertExpiryMargin
+	}
+	maxRequotes := params.MaxRequotes
+	if maxRequotes < 0 {
+		maxRequotes = 0
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRequotes; attempt++ {
+		quoteID, err := c.CreateQuoteWithContext(ctx, &CreateQuotePayload{
+			FromCoin: params.FromCoin,
+			ToCoin:   params.ToCoin,
+			Size:     params.Size,
+		})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		quote, err := c.waitForQuote(ctx, quoteID, pollInterval, func(quote *QuoteStatus) bool {
+			return quote.Price.IsPositive() || quote.Expired
+		})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		lastErr = c.checkQuote(ctx, quote, params, expiryMargin)
+		if errors.Is(lastErr, ErrQuoteRejected) || errors.Is(lastErr, ErrQuoteExpired) {
+			continue
+		}
+		if lastErr != nil {
+			return nil, errors.WithStack(lastErr)
+		}
+
+		err = c.AcceptQuoteWithContext(ctx, quoteID)
+		if err != nil {
+			if isQuoteExpiredError(err) {
+				lastErr = errors.Wrap(ErrQuoteExpired, err.Error())
+				continue
+			}
+			return nil, errors.WithStack(err)
+		}
+
+		quote, err = c.waitForQuote(ctx, quoteID, pollInterval, func(quote *QuoteStatus) bool {
+			return quote.Filled || quote.Expired
+		})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if !quote.Filled {
+			lastErr = errors.Wrapf(ErrQuoteExpired, "quote %d", quoteID)
+			continue
+		}
+
+		return quote, nil
+	}
+
+	return nil, errors.Wrapf(lastErr, "after %d quotes", maxRequotes+1)
+}
+
+func (c *Converts) waitForQuote(ctx context.Context, quoteID int64, pollInterval time.Duration, done func(*QuoteStatus) bool) (*QuoteStatus, error) {
+	for {
+		quotes, err := c.GetQuotesWithContext(ctx, quoteID, nil)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if len(quotes) == 0 {
+			return nil, errors.Errorf("quote %d not found", quoteID)
+		}
+
+		if done(&quotes[0]) {
+			return &quotes[0], nil
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return nil, errors.WithStack(ctx.Err())
+		}
+	}
+}
+
+func (c *Converts) checkQuote(ctx context.Context, quote *QuoteStatus, params *ConvertParams, expiryMargin time.Duration) error {
+	if quote.Expired || (quote.Expiry.Time.Unix() > 0 && time.Until(quote.Expiry.Time) < expiryMargin) {
+		return errors.Wrapf(ErrQuoteExpired, "quote %d", quote.ID)
+	}
+
+	// Buying the base coin needs a low price, selling it a high one.
+	worse := func(price, limit decimal.Decimal) bool {
+		if quote.Side == SideBuy {
+			return price.GreaterThan(limit)
+		}
+		return price.LessThan(limit)
+	}
+
+	if params.LimitPrice != nil && worse(quote.Price, *params.LimitPrice) {
+		return errors.Wrapf(ErrQuoteRejected, "price %s beyond limit %s", quote.Price, params.LimitPrice)
+	}
+
+	if params.MaxSlippage != nil {
+		market, err := c.client.Markets.GetMarketByNameWithContext(ctx, fmt.Sprintf("%s/%s", quote.BaseCoin, quote.QuoteCoin))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		mid := market.Bid.Add(market.Ask).Div(decimal.NewFromInt(2))
+		limit := mid.Mul(decimal.NewFromInt(1).Add(*params.MaxSlippage))
+		if quote.Side != SideBuy {
+			limit = mid.Mul(decimal.NewFromInt(1).Sub(*params.MaxSlippage))
+		}
+		if worse(quote.Price, limit) {
+			return errors.Wrapf(ErrQuoteRejected, "price %s slipped beyond %s from mid %s", quote.Price, params.MaxSlippage, mid)
+		}
+	}
+
+	return nil
+}
+
+func isQuoteExpiredError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && strings.Contains(strings.ToLower(apiErr.Message), "expired")
+}
@@ -1,6 +1,7 @@
 package goftx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -27,6 +28,7 @@ type QuoteStatus struct {
 	QuoteCoin string          `json:"quoteCoin"`
 	Side      string            `json:"side"`
 	ToCoin    string          `json:"toCoin"`
+	Expiry    FTXTime         `json:"expiry"`
 }
 
 type CreateQuotePayload struct {
@@ -36,15 +38,19 @@ type CreateQuotePayload struct {
 }
 
 func (c *Converts) CreateQuote(payload *CreateQuotePayload) (int64, error) {
+	return c.CreateQuoteWithContext(context.Background(), payload)
+}
+
+func (c *Converts) CreateQuoteWithContext(ctx context.Context, payload *CreateQuotePayload) (int64, error) {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return 0, errors.WithStack(err)
 	}
 
-	request, err := c.client.prepareRequest(Request{
+	request, err := c.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiQuotes),
+		URL:    fmt.Sprintf("%s%s", c.client.apiUrl, apiQuotes),
 		Body:   body,
 	})
 	if err != nil {
@@ -68,15 +74,19 @@ func (c *Converts) CreateQuote(payload *CreateQuotePayload) (int64, error) {
 }
 
 func (c *Converts) GetQuotes(quoteID int64, market *string) ([]QuoteStatus, error) {
+	return c.GetQuotesWithContext(context.Background(), quoteID, market)
+}
+
+func (c *Converts) GetQuotesWithContext(ctx context.Context, quoteID int64, market *string) ([]QuoteStatus, error) {
 	queryParams := make(map[string]string)
 	if market != nil {
 		queryParams["market"] = *market
 	}
 
-	request, err := c.client.prepareRequest(Request{
+	request, err := c.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s/%d", apiUrl, apiQuotes, quoteID),
+		URL:    fmt.Sprintf("%s%s/%d", c.client.apiUrl, apiQuotes, quoteID),
 		Params: queryParams,
 	})
 	if err != nil {
@@ -98,10 +108,14 @@ func (c *Converts) GetQuotes(quoteID int64, market *string) ([]QuoteStatus, erro
 }
 
 func (c *Converts) AcceptQuote(quoteID int64) error {
-	request, err := c.client.prepareRequest(Request{
+	return c.AcceptQuoteWithContext(context.Background(), quoteID)
+}
+
+func (c *Converts) AcceptQuoteWithContext(ctx context.Context, quoteID int64) error {
+	request, err := c.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s/%d/accept", apiUrl, apiQuotes, quoteID),
+		URL:    fmt.Sprintf("%s%s/%d/accept", c.client.apiUrl, apiQuotes, quoteID),
 	})
 	if err != nil {
 		return errors.WithStack(err)
@@ -0,0 +1,107 @@
+package goftx
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors an *APIError can be matched against with errors.Is.
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrOrderClosed       = errors.New("order already closed")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrInvalidNonce      = errors.New("invalid nonce")
+	ErrAuthFailed        = errors.New("authentication failed")
+)
+
+// APIError is returned by every REST method when the exchange rejects a request.
+// It is wrapped with a stack trace, use errors.As to get it back.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Method     string
+	Endpoint   string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s	Status Code: %d	Error: %v", e.Method, e.Endpoint, e.StatusCode, e.Message)
+}
+
+func (e *APIError) Is(target error) bool {
+	message := strings.ToLower(e.Message)
+
+	switch target {
+	case ErrInsufficientFunds:
+		return strings.Contains(message, "not enough balances") ||
+			strings.Contains(message, "not enough margin") ||
+			strings.Contains(message, "insufficient")
+	case ErrOrderNotFound:
+		return strings.Contains(message, "order not found")
+	case ErrOrderClosed:
+		return strings.Contains(message, "order already closed") ||
+			strings.Contains(message, "order already queued for cancellation")
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests ||
+			strings.Contains(message, "do not send more than") ||
+			strings.Contains(message, "rate limit")
+	case ErrInvalidNonce:
+		return strings.Contains(message, "nonce")
+	case ErrAuthFailed:
+		return e.StatusCode == http.StatusUnauthorized ||
+			strings.HasPrefix(message, "not logged in") ||
+			strings.Contains(message, "invalid api key") ||
+			strings.Contains(message, "invalid signature")
+	}
+
+	return false
+}
+
+// Retryable reports whether sending the same request again may succeed.
+func (e *APIError) Retryable() bool {
+	if e.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+
+	message := strings.ToLower(e.Message)
+	return e.Is(ErrRateLimited) ||
+		e.Is(ErrInvalidNonce) ||
+		strings.Contains(message, "try again") ||
+		strings.Contains(message, "please retry")
+}
+
+func IsInsufficientFunds(err error) bool {
+	return errors.Is(err, ErrInsufficientFunds)
+}
+
+func IsOrderNotFound(err error) bool {
+	return errors.Is(err, ErrOrderNotFound)
+}
+
+func IsOrderClosed(err error) bool {
+	return errors.Is(err, ErrOrderClosed)
+}
+
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+func IsInvalidNonce(err error) bool {
+	return errors.Is(err, ErrInvalidNonce)
+}
+
+func IsAuthFailure(err error) bool {
+	return errors.Is(err, ErrAuthFailed)
+}
+
+// IsRetryable reports whether err is an *APIError worth retrying.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package goftx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -44,15 +45,19 @@ type Fill struct {
 }
 
 func (f *Fills) GetFills(params *GetFillsParams) ([]Fill, error) {
+	return f.GetFillsWithContext(context.Background(), params)
+}
+
+func (f *Fills) GetFillsWithContext(ctx context.Context, params *GetFillsParams) ([]Fill, error) {
 	queryParams, err := PrepareQueryParams(params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := f.client.prepareRequest(Request{
+	request, err := f.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiFills),
+		URL:    fmt.Sprintf("%s%s", f.client.apiUrl, apiFills),
 		Params: queryParams,
 	})
 	if err != nil {
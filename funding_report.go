@@ -0,0 +1,117 @@
+package goftx
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// FundingReport sums the funding payments of the account, amounts are P&L:
+// positive when funding was received and negative when it was paid.
+type FundingReport struct {
+	Total   decimal.Decimal
+	Futures []FutureFunding
+}
+
+// FutureFunding is the funding P&L of a single future.
+// NetSize, NextFundingRate, NextFundingTime and ExpectedNextPayment are only set for open positions,
+// ExpectedNextPayment is estimated from the current mark price.
+type FutureFunding struct {
+	Future              string
+	Total               decimal.Decimal
+	Days                []DailyFunding
+	NetSize             decimal.Decimal
+	NextFundingRate     decimal.Decimal
+	NextFundingTime     time.Time
+	ExpectedNextPayment decimal.Decimal
+}
+
+// DailyFunding is the funding P&L of a UTC day.
+type DailyFunding struct {
+	Day   time.Time
+	Total decimal.Decimal
+}
+
+// GetFundingReport fetches every funding payment matching params and the open positions of the account.
+func (f *Futures) GetFundingReport(ctx context.Context, params *GetFundingPaymentsParams) (*FundingReport, error) {
+	if params == nil {
+		params = &GetFundingPaymentsParams{}
+	}
+
+	futures := make(map[string]*FutureFunding)
+	days := make(map[string]map[time.Time]decimal.Decimal)
+	future := func(name string) *FutureFunding {
+		if _, ok := futures[name]; !ok {
+			futures[name] = &FutureFunding{Future: name}
+			days[name] = make(map[time.Time]decimal.Decimal)
+		}
+		return futures[name]
+	}
+
+	it := f.IterateFundingPayments(ctx, params)
+	for it.Next() {
+		payment := it.FundingPayment()
+		pnl := payment.Payment.Neg()
+		day := payment.Time.UTC().Truncate(24 * time.Hour)
+
+		funding := future(payment.Future)
+		funding.Total = funding.Total.Add(pnl)
+		days[payment.Future][day] = days[payment.Future][day].Add(pnl)
+	}
+	if err := it.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	positions, err := f.client.Account.GetPositionsWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, position := range positions {
+		if position.NetSize.IsZero() || (params.Future != nil && position.Future != *params.Future) {
+			continue
+		}
+
+		stats, err := f.GetFutureStatsWithContext(ctx, position.Future)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if stats.NextFundingTime.IsZero() {
+			// Only perpetual futures are funded.
+			continue
+		}
+
+		market, err := f.GetFutureWithContext(ctx, position.Future)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		funding := future(position.Future)
+		funding.NetSize = position.NetSize
+		funding.NextFundingRate = decimal.NewFromFloat(stats.NextFundingRate)
+		funding.NextFundingTime = stats.NextFundingTime
+		// Longs pay a positive rate on the position value at the mark price.
+		funding.ExpectedNextPayment = position.NetSize.Mul(market.Mark).Mul(funding.NextFundingRate).Neg()
+	}
+
+	report := &FundingReport{}
+	for name, funding := range futures {
+		for day, total := range days[name] {
+			funding.Days = append(funding.Days, DailyFunding{Day: day, Total: total})
+		}
+		sort.Slice(funding.Days, func(i, j int) bool {
+			return funding.Days[i].Day.Before(funding.Days[j].Day)
+		})
+
+		report.Total = report.Total.Add(funding.Total)
+		report.Futures = append(report.Futures, *funding)
+	}
+	sort.Slice(report.Futures, func(i, j int) bool {
+		return report.Futures[i].Future < report.Futures[j].Future
+	})
+
+	return report, nil
+}
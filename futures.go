@@ -1,6 +1,7 @@
 package goftx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -82,6 +83,21 @@ type GetFundingRatesParams struct {
 	Future    *string `json:"future"`
 }
 
+type GetFundingPaymentsParams struct {
+	StartTime *int    `json:"start_time"`
+	EndTime   *int    `json:"end_time"`
+	Future    *string `json:"future"`
+}
+
+// A positive Payment was paid by the account, a negative one was received.
+type FundingPayment struct {
+	ID      int64           `json:"id"`
+	Future  string          `json:"future"`
+	Payment decimal.Decimal `json:"payment"`
+	Rate    decimal.Decimal `json:"rate"`
+	Time    time.Time       `json:"time"`
+}
+
 type FundingRate struct {
 	Future string          `json:"future"`
 	Rate   decimal.Decimal `json:"rate"`
@@ -106,11 +122,12 @@ type HistoricalIndex struct {
 }
 
 const (
-	apiFutures        = "/futures"
-	apiFundingRates   = "/funding_rates"
-	apiIndexWeights   = "/indexes/%s/weights"
-	apiIndexCandles   = "/indexes/%s/candles"
-	apiExpiredFutures = "expired_futures"
+	apiFutures         = "/futures"
+	apiFundingRates    = "/funding_rates"
+	apiFundingPayments = "/funding_payments"
+	apiIndexWeights    = "/indexes/%s/weights"
+	apiIndexCandles    = "/indexes/%s/candles"
+	apiExpiredFutures  = "expired_futures"
 )
 
 type Futures struct {
@@ -118,9 +135,13 @@ type Futures struct {
 }
 
 func (f *Futures) GetFutures() ([]Future, error) {
-	request, err := f.client.prepareRequest(Request{
+	return f.GetFuturesWithContext(context.Background())
+}
+
+func (f *Futures) GetFuturesWithContext(ctx context.Context) ([]Future, error) {
+	request, err := f.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiFutures),
+		URL:    fmt.Sprintf("%s%s", f.client.apiUrl, apiFutures),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -141,9 +162,13 @@ func (f *Futures) GetFutures() ([]Future, error) {
 }
 
 func (f *Futures) GetFuture(name string) (*Future, error) {
-	request, err := f.client.prepareRequest(Request{
+	return f.GetFutureWithContext(context.Background(), name)
+}
+
+func (f *Futures) GetFutureWithContext(ctx context.Context, name string) (*Future, error) {
+	request, err := f.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s/%s", apiUrl, apiFutures, name),
+		URL:    fmt.Sprintf("%s%s/%s", f.client.apiUrl, apiFutures, name),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -164,9 +189,13 @@ func (f *Futures) GetFuture(name string) (*Future, error) {
 }
 
 func (f *Futures) GetFutureStats(name string) (*FutureStats, error) {
-	request, err := f.client.prepareRequest(Request{
+	return f.GetFutureStatsWithContext(context.Background(), name)
+}
+
+func (f *Futures) GetFutureStatsWithContext(ctx context.Context, name string) (*FutureStats, error) {
+	request, err := f.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s/%s/stats", apiUrl, apiFutures, name),
+		URL:    fmt.Sprintf("%s%s/%s/stats", f.client.apiUrl, apiFutures, name),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -187,14 +216,18 @@ func (f *Futures) GetFutureStats(name string) (*FutureStats, error) {
 }
 
 func (f *Futures) GetFundingRates(params *GetFundingRatesParams) ([]FundingRate, error) {
+	return f.GetFundingRatesWithContext(context.Background(), params)
+}
+
+func (f *Futures) GetFundingRatesWithContext(ctx context.Context, params *GetFundingRatesParams) ([]FundingRate, error) {
 	queryParams, err := PrepareQueryParams(params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := f.client.prepareRequest(Request{
+	request, err := f.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiFundingRates),
+		URL:    fmt.Sprintf("%s%s", f.client.apiUrl, apiFundingRates),
 		Params: queryParams,
 	})
 	if err != nil {
@@ -215,10 +248,48 @@ func (f *Futures) GetFundingRates(params *GetFundingRatesParams) ([]FundingRate,
 	return result, nil
 }
 
+func (f *Futures) GetFundingPayments(params *GetFundingPaymentsParams) ([]FundingPayment, error) {
+	return f.GetFundingPaymentsWithContext(context.Background(), params)
+}
+
+func (f *Futures) GetFundingPaymentsWithContext(ctx context.Context, params *GetFundingPaymentsParams) ([]FundingPayment, error) {
+	queryParams, err := PrepareQueryParams(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := f.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", f.client.apiUrl, apiFundingPayments),
+		Params: queryParams,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := f.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []FundingPayment
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
 func (f *Futures) GetIndexWeights(indexName string) (map[string]decimal.Decimal, error) {
-	request, err := f.client.prepareRequest(Request{
+	return f.GetIndexWeightsWithContext(context.Background(), indexName)
+}
+
+func (f *Futures) GetIndexWeightsWithContext(ctx context.Context, indexName string) (map[string]decimal.Decimal, error) {
+	request, err := f.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, fmt.Sprintf(apiIndexWeights, indexName)),
+		URL:    fmt.Sprintf("%s%s", f.client.apiUrl, fmt.Sprintf(apiIndexWeights, indexName)),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -239,9 +310,13 @@ func (f *Futures) GetIndexWeights(indexName string) (map[string]decimal.Decimal,
 }
 
 func (f *Futures) GetExpiredFutures() ([]FutureExpired, error) {
-	request, err := f.client.prepareRequest(Request{
+	return f.GetExpiredFuturesWithContext(context.Background())
+}
+
+func (f *Futures) GetExpiredFuturesWithContext(ctx context.Context) ([]FutureExpired, error) {
+	request, err := f.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiExpiredFutures),
+		URL:    fmt.Sprintf("%s%s", f.client.apiUrl, apiExpiredFutures),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -262,14 +337,18 @@ func (f *Futures) GetExpiredFutures() ([]FutureExpired, error) {
 }
 
 func (f *Futures) GetHistoricalIndex(market string, params *GetHistoricalIndexParams) ([]HistoricalIndex, error) {
+	return f.GetHistoricalIndexWithContext(context.Background(), market, params)
+}
+
+func (f *Futures) GetHistoricalIndexWithContext(ctx context.Context, market string, params *GetHistoricalIndexParams) ([]HistoricalIndex, error) {
 	queryParams, err := PrepareQueryParams(params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := f.client.prepareRequest(Request{
+	request, err := f.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, fmt.Sprintf(apiIndexCandles, market)),
+		URL:    fmt.Sprintf("%s%s", f.client.apiUrl, fmt.Sprintf(apiIndexCandles, market)),
 		Params: queryParams,
 	})
 	if err != nil {
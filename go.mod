@@ -3,6 +3,7 @@ module github.com/wizpacekorea/goftx
 go 1.14
 
 require (
+	github.com/gorilla/websocket v1.4.2
 	github.com/pkg/errors v0.9.1
 	github.com/shopspring/decimal v1.2.0
 )
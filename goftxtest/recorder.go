@@ -0,0 +1,293 @@
+package goftxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const redacted = "REDACTED"
+
+// secretBodyFields are request body fields redacted like the key and signature headers.
+var secretBodyFields = []string{"password", "code"}
+
+type Mode int
+
+const (
+	// ModeRecord sends requests to the real transport and appends every exchange to the cassette.
+	ModeRecord Mode = iota
+	// ModeReplay answers requests from the cassette without any network access.
+	ModeReplay
+)
+
+// Cassette is the file format written by a recording Recorder.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   string            `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records client sessions to a cassette file
+// or replays them, use it with goftx.WithHTTPClient(recorder.HTTPClient()).
+//
+// The API key and signature headers and the withdrawal password and 2FA code in request bodies
+// are redacted before anything is written.
+// In replay mode requests are matched on method, path and query; identical requests are
+// answered in recording order and the last answer is repeated once they are used up.
+type Recorder struct {
+	mu           sync.Mutex
+	mode         Mode
+	path         string
+	transport    http.RoundTripper
+	cassette     Cassette
+	used         []bool
+	ignoreParams map[string]struct{}
+}
+
+// NewRecorder records every request sent through transport, http.DefaultTransport when nil, into path.
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{
+		mode:         ModeRecord,
+		path:         path,
+		transport:    transport,
+		ignoreParams: make(map[string]struct{}),
+	}
+}
+
+// NewReplayer serves the interactions recorded in path.
+func NewReplayer(path string) (*Recorder, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var cassette Cassette
+	err = json.Unmarshal(data, &cassette)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &Recorder{
+		mode:         ModeReplay,
+		path:         path,
+		cassette:     cassette,
+		used:         make([]bool, len(cassette.Interactions)),
+		ignoreParams: make(map[string]struct{}),
+	}, nil
+}
+
+// IgnoreParams excludes query parameters from replay matching, e.g. an end_time defaulting to now.
+func (r *Recorder) IgnoreParams(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		r.ignoreParams[name] = struct{}{}
+	}
+}
+
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip reads the request body and sends a clone carrying it, req itself is not modified.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	outgoing := req.Clone(req.Context())
+	if req.Body != nil {
+		outgoing.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	resp.Request = req
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Query:   req.URL.RawQuery,
+			Headers: recordHeaders(req.Header),
+			Body:    redactBody(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    recordHeaders(resp.Header),
+			Body:       string(respBody),
+		},
+	})
+
+	// The cassette is rewritten after every request so that a crashed session is still usable.
+	err = r.save()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := r.normalizeQuery(req.URL.RawQuery)
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		recorded := interaction.Request
+		if recorded.Method != req.Method || recorded.Path != req.URL.Path || r.normalizeQuery(recorded.Query) != query {
+			continue
+		}
+
+		last = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, errors.Errorf("no recorded interaction for %s %s?%s", req.Method, req.URL.Path, req.URL.RawQuery)
+	}
+	r.used[last] = true
+
+	recorded := r.cassette.Interactions[last].Response
+	header := make(http.Header)
+	for k, v := range recorded.Headers {
+		header.Set(k, v)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(ioutil.WriteFile(r.path, data, os.FileMode(0644)))
+}
+
+func (r *Recorder) normalizeQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	for name := range r.ignoreParams {
+		values.Del(name)
+	}
+	return values.Encode()
+}
+
+func recordHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for name := range header {
+		value := header.Get(name)
+		if isSecretHeader(name) {
+			value = redacted
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// redactBody replaces the secret fields of a JSON object body, other bodies are recorded unchanged.
+func redactBody(body []byte) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return string(body)
+	}
+
+	found := false
+	for _, name := range secretBodyFields {
+		if _, ok := fields[name]; ok {
+			fields[name] = json.RawMessage(`"` + redacted + `"`)
+			found = true
+		}
+	}
+	if !found {
+		return string(body)
+	}
+
+	redactedBody, err := json.Marshal(fields)
+	if err != nil {
+		return string(body)
+	}
+	return string(redactedBody)
+}
+
+// isSecretHeader matches the key and signature headers of every host profile (FTX-KEY, FTXUS-SIGN, ...).
+func isSecretHeader(name string) bool {
+	name = strings.ToUpper(name)
+	return strings.HasPrefix(name, "FTX") && (strings.HasSuffix(name, "-KEY") || strings.HasSuffix(name, "-SIGN"))
+}
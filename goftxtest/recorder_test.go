@@ -0,0 +1,106 @@
+package goftxtest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wizpacekorea/goftx"
+	"github.com/wizpacekorea/goftx/goftxtest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := newServer(t)
+	server.SetBalance("", goftx.Balance{Coin: "USD", Free: dec("100"), Total: dec("100")})
+	dir, err := ioutil.TempDir("", "goftxtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "session.json")
+
+	recorder := goftxtest.NewRecorder(path, server.Server.Client().Transport)
+	client := server.Client(goftx.WithHTTPClient(recorder.HTTPClient()))
+
+	placed, err := client.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market: "BTC/USD",
+		Side:   goftx.SideSell,
+		Type:   goftx.OrderTypeLimitOrder,
+		Price:  dec("40000"),
+		Size:   dec("0.25"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordedBalances, err := client.GetWalletBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The fake has no wallet endpoints, the failed withdrawal is recorded all the same.
+	password, code := "hunter2", "123456"
+	_, err = client.Withdraw(&goftx.WithdrawPayload{
+		Coin:     "USD",
+		Size:     dec("10"),
+		Address:  "0xabc",
+		Password: &password,
+		Code:     &code,
+	})
+	if err == nil {
+		t.Fatal("withdrawal succeeded on the fake server")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{password, code, goftxtest.DefaultAPIKey} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+	if interactions := recorder.Interactions(); len(interactions) != 3 {
+		t.Fatalf("recorded %d interactions, want 3", len(interactions))
+	}
+
+	replayer, err := goftxtest.NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+	client = goftx.New(
+		goftx.WithHTTPClient(replayer.HTTPClient()),
+		goftx.WithBaseURL(server.APIURL()),
+		goftx.WithAuth("other-key", "other-secret"),
+		goftx.WithRateLimiter(nil),
+	)
+
+	replayed, err := client.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market: "BTC/USD",
+		Side:   goftx.SideSell,
+		Type:   goftx.OrderTypeLimitOrder,
+		Price:  dec("40000"),
+		Size:   dec("0.25"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.ID != placed.ID || !replayed.Price.Equal(placed.Price) {
+		t.Errorf("replayed order = %+v, want %+v", replayed, placed)
+	}
+
+	balances, err := client.GetWalletBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != len(recordedBalances) || !balances[0].Total.Equal(recordedBalances[0].Total) {
+		t.Errorf("replayed balances = %+v, want %+v", balances, recordedBalances)
+	}
+
+	_, err = client.GetOpenOrders("BTC/USD")
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("unrecorded request error = %v", err)
+	}
+}
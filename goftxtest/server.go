@@ -0,0 +1,820 @@
+// Package goftxtest provides an in-process fake of the FTX REST API for tests.
+//
+// The fake keeps markets, futures, orderbooks, orders, fills, positions, balances and subaccounts in memory
+// and checks the FTX-KEY, FTX-SIGN and FTX-TS headers of private requests exactly like the exchange:
+//
+//	server := goftxtest.NewServer()
+//	defer server.Close()
+//
+//	server.AddMarket(goftx.Market{Name: "BTC/USD", PriceIncrement: decimal.NewFromFloat(0.5)})
+//	client := server.Client()
+//	order, err := client.PlaceOrder(&goftx.PlaceOrderPayload{...})
+//
+// Orders are never matched automatically, use FillOrder to simulate executions.
+package goftxtest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/wizpacekorea/goftx"
+)
+
+const (
+	DefaultAPIKey = "test-key"
+	DefaultSecret = "test-secret"
+
+	apiPrefix = "/api"
+
+	keyHeader        = "FTX-KEY"
+	signHeader       = "FTX-SIGN"
+	tsHeader         = "FTX-TS"
+	subAccountHeader = "FTX-SUBACCOUNT"
+
+	// mainAccount is the key of the main account state, subaccounts are keyed by nickname.
+	mainAccount = ""
+)
+
+type Option func(s *Server)
+
+func WithCredentials(key, secret string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+		s.secret = secret
+	}
+}
+
+// WithClock replaces time.Now for order, fill and transfer timestamps and for /time.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithNonceWindow rejects requests whose FTX-TS is further than window from the server clock.
+// It is disabled by default so that tests using WithClock do not have to sync the client time.
+func WithNonceWindow(window time.Duration) Option {
+	return func(s *Server) {
+		s.nonceWindow = window
+	}
+}
+
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	apiKey      string
+	secret      string
+	now         func() time.Time
+	nonceWindow time.Duration
+	nextID      int64
+	markets     map[string]goftx.Market
+	futures     map[string]goftx.Future
+	orderBooks  map[string]goftx.OrderBook
+	trades      map[string][]goftx.Trade
+	subAccounts map[string]goftx.SubAccount
+	accounts    map[string]*account
+	orders      map[int64]*order
+}
+
+type account struct {
+	info      goftx.AccountInformation
+	balances  map[string]*goftx.Balance
+	positions map[string]goftx.Position
+	fills     []goftx.Fill
+}
+
+type order struct {
+	goftx.Order
+	account string
+}
+
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		apiKey:      DefaultAPIKey,
+		secret:      DefaultSecret,
+		now:         time.Now,
+		markets:     make(map[string]goftx.Market),
+		futures:     make(map[string]goftx.Future),
+		orderBooks:  make(map[string]goftx.OrderBook),
+		trades:      make(map[string][]goftx.Trade),
+		subAccounts: make(map[string]goftx.SubAccount),
+		accounts:    make(map[string]*account),
+		orders:      make(map[int64]*order),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL of the fake REST API, to be used with goftx.WithBaseURL.
+func (s *Server) APIURL() string {
+	return s.Server.URL + apiPrefix
+}
+
+// Client returns a goftx.Client authenticated against the main account of the fake server,
+// opts are applied after the server settings.
+func (s *Server) Client(opts ...goftx.Option) *goftx.Client {
+	return s.SubAccountClient("", opts...)
+}
+
+// SubAccountClient returns a goftx.Client authenticated against subAccount.
+func (s *Server) SubAccountClient(subAccount string, opts ...goftx.Option) *goftx.Client {
+	auth := goftx.WithAuth(s.apiKey, s.secret)
+	if subAccount != mainAccount {
+		auth = goftx.WithAuth(s.apiKey, s.secret, subAccount)
+	}
+
+	options := []goftx.Option{
+		goftx.WithHTTPClient(s.Server.Client()),
+		goftx.WithBaseURL(s.APIURL()),
+		goftx.WithOTCBaseURL(s.APIURL()),
+		auth,
+		goftx.WithRateLimiter(nil),
+	}
+	return goftx.New(append(options, opts...)...)
+}
+
+func (s *Server) AddMarket(market goftx.Market) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markets[market.Name] = market
+}
+
+func (s *Server) AddFuture(future goftx.Future) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.futures[future.Name] = future
+}
+
+func (s *Server) SetOrderBook(market string, orderBook goftx.OrderBook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orderBooks[market] = orderBook
+}
+
+// AddTrades stores public trades of market, they are served newest first.
+func (s *Server) AddTrades(market string, trades ...goftx.Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trades[market] = append(s.trades[market], trades...)
+}
+
+func (s *Server) AddSubAccount(nickname string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subAccounts[nickname] = goftx.SubAccount{Nickname: nickname, Deletable: true, Editable: true}
+}
+
+// SetBalance sets the coin balance of subAccount, use "" for the main account.
+func (s *Server) SetBalance(subAccount string, balance goftx.Balance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := balance
+	s.account(subAccount).balances[balance.Coin] = &b
+}
+
+func (s *Server) Balance(subAccount, coin string) goftx.Balance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if balance, ok := s.account(subAccount).balances[coin]; ok {
+		return *balance
+	}
+	return goftx.Balance{Coin: coin}
+}
+
+func (s *Server) SetPosition(subAccount string, position goftx.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account(subAccount).positions[position.Future] = position
+}
+
+// SetAccountInformation sets what /account returns for subAccount, Positions are filled in by the server.
+func (s *Server) SetAccountInformation(subAccount string, info goftx.AccountInformation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account(subAccount).info = info
+}
+
+// Order returns the current state of an order placed through the server.
+func (s *Server) Order(orderID int64) (goftx.Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orders[orderID]
+	if !ok {
+		return goftx.Order{}, false
+	}
+	return o.Order, true
+}
+
+// FillOrder executes size of an open order at price and records the fill for its account.
+// size is capped at the remaining size of the order, nothing is filled when it is not positive.
+func (s *Server) FillOrder(orderID int64, size, price decimal.Decimal, liquidity string) (goftx.Fill, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[orderID]
+	if !ok || o.Status == goftx.OrderStatusClosed || !size.IsPositive() {
+		return goftx.Fill{}, false
+	}
+	if size.GreaterThan(o.RemainingSize) {
+		size = o.RemainingSize
+	}
+
+	filled := o.FilledSize.Add(size)
+	o.AvgFillPrice = o.AvgFillPrice.Mul(o.FilledSize).Add(price.Mul(size)).Div(filled)
+	o.FilledSize = filled
+	o.RemainingSize = o.Size.Sub(filled)
+	if o.RemainingSize.IsZero() {
+		o.Status = goftx.OrderStatusClosed
+	}
+
+	market := s.markets[o.Market]
+	fill := goftx.Fill{
+		ID:            s.id(),
+		Future:        o.Future,
+		Liquidity:     liquidity,
+		Market:        o.Market,
+		BaseCurrency:  market.BaseCurrency,
+		QuoteCurrency: market.QuoteCurrency,
+		OrderID:       o.ID,
+		TradeID:       s.id(),
+		Price:         price,
+		Side:          o.Side,
+		Size:          size,
+		Time:          goftx.FTXTime{Time: s.now()},
+		Type:          "order",
+	}
+	acc := s.account(o.account)
+	acc.fills = append(acc.fills, fill)
+
+	return fill, true
+}
+
+func (s *Server) account(name string) *account {
+	acc, ok := s.accounts[name]
+	if !ok {
+		acc = &account{
+			balances:  make(map[string]*goftx.Balance),
+			positions: make(map[string]goftx.Position),
+		}
+		s.accounts[name] = acc
+	}
+	return acc
+}
+
+func (s *Server) id() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+type response struct {
+	Success bool        `json:"success"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+type apiError struct {
+	status  int
+	message string
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, response{Error: err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	result, apiErr := s.route(r, body)
+	s.mu.Unlock()
+
+	if apiErr != nil {
+		writeJSON(w, apiErr.status, response{Error: apiErr.message})
+		return
+	}
+	writeJSON(w, http.StatusOK, response{Success: true, Result: result})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func (s *Server) route(r *http.Request, body []byte) (interface{}, *apiError) {
+	path := strings.TrimPrefix(r.URL.Path, apiPrefix)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	// Public endpoints.
+	switch {
+	case r.Method == http.MethodGet && path == "/time":
+		return s.now().UTC(), nil
+	case r.Method == http.MethodGet && parts[0] == "markets":
+		return s.routeMarkets(r, parts)
+	case r.Method == http.MethodGet && parts[0] == "futures":
+		return s.routeFutures(parts)
+	}
+
+	accountName, apiErr := s.authenticate(r, body)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	switch {
+	case parts[0] == "orders":
+		return s.routeOrders(r, parts, body, accountName)
+	case r.Method == http.MethodGet && path == "/fills":
+		return s.getFills(r, accountName)
+	case r.Method == http.MethodGet && path == "/positions":
+		return s.getPositions(accountName), nil
+	case r.Method == http.MethodGet && path == "/account":
+		info := s.account(accountName).info
+		info.Positions = s.getPositions(accountName)
+		return info, nil
+	case r.Method == http.MethodGet && path == "/wallet/balances":
+		return s.getBalances(accountName), nil
+	case parts[0] == "subaccounts":
+		return s.routeSubAccounts(r, parts, body)
+	}
+
+	return nil, &apiError{status: http.StatusNotFound, message: "Not found"}
+}
+
+// authenticate verifies the request signature and returns the subaccount it is made for.
+func (s *Server) authenticate(r *http.Request, body []byte) (string, *apiError) {
+	key := r.Header.Get(keyHeader)
+	ts := r.Header.Get(tsHeader)
+	if key == "" || ts == "" || r.Header.Get(signHeader) == "" {
+		return "", &apiError{status: http.StatusUnauthorized, message: "Not logged in"}
+	}
+	if key != s.apiKey {
+		return "", &apiError{status: http.StatusUnauthorized, message: "Not logged in: Invalid API key"}
+	}
+
+	nonce, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return "", &apiError{status: http.StatusUnauthorized, message: "Not logged in: Invalid nonce"}
+	}
+	if s.nonceWindow > 0 {
+		drift := s.now().Sub(time.Unix(0, nonce*int64(time.Millisecond)))
+		if drift > s.nonceWindow || drift < -s.nonceWindow {
+			return "", &apiError{status: http.StatusUnauthorized, message: "Not logged in: Invalid nonce"}
+		}
+	}
+
+	payload := ts + r.Method + r.URL.Path
+	if r.URL.RawQuery != "" {
+		payload += "?" + r.URL.RawQuery
+	}
+	payload += string(body)
+
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte(payload))
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(r.Header.Get(signHeader))) {
+		return "", &apiError{status: http.StatusUnauthorized, message: "Not logged in: Invalid signature"}
+	}
+
+	subAccount, err := url.PathUnescape(r.Header.Get(subAccountHeader))
+	if err != nil {
+		return "", &apiError{status: http.StatusBadRequest, message: "Invalid subaccount"}
+	}
+	if subAccount != mainAccount {
+		if _, ok := s.subAccounts[subAccount]; !ok {
+			return "", &apiError{status: http.StatusUnauthorized, message: "Not logged in: Invalid subaccount"}
+		}
+	}
+
+	return subAccount, nil
+}
+
+func (s *Server) routeMarkets(r *http.Request, parts []string) (interface{}, *apiError) {
+	if len(parts) == 1 {
+		markets := make([]goftx.Market, 0, len(s.markets))
+		for _, market := range s.markets {
+			markets = append(markets, market)
+		}
+		return markets, nil
+	}
+
+	name := parts[1]
+	if len(parts) > 2 {
+		name = parts[1] + "/" + parts[2]
+		if _, ok := s.markets[name]; !ok {
+			name = parts[1]
+		}
+	}
+	market, ok := s.markets[name]
+	if !ok {
+		return nil, &apiError{status: http.StatusNotFound, message: "No such market: " + name}
+	}
+
+	switch parts[len(parts)-1] {
+	case "orderbook":
+		orderBook := s.orderBooks[name]
+		if depth, err := strconv.Atoi(r.URL.Query().Get("depth")); err == nil {
+			if depth < len(orderBook.Bids) {
+				orderBook.Bids = orderBook.Bids[:depth]
+			}
+			if depth < len(orderBook.Asks) {
+				orderBook.Asks = orderBook.Asks[:depth]
+			}
+		}
+		return orderBook, nil
+	case "trades":
+		trades := s.trades[name]
+		result := make([]goftx.Trade, 0, len(trades))
+		for i := len(trades) - 1; i >= 0; i-- {
+			if inTimeRange(r, trades[i].Time) {
+				result = append(result, trades[i])
+			}
+		}
+		return limit(r, len(result), func(n int) interface{} { return result[:n] }), nil
+	}
+
+	return market, nil
+}
+
+func (s *Server) routeFutures(parts []string) (interface{}, *apiError) {
+	if len(parts) == 1 {
+		futures := make([]goftx.Future, 0, len(s.futures))
+		for _, future := range s.futures {
+			futures = append(futures, future)
+		}
+		return futures, nil
+	}
+
+	future, ok := s.futures[parts[1]]
+	if !ok {
+		return nil, &apiError{status: http.StatusNotFound, message: "No such future: " + parts[1]}
+	}
+	return future, nil
+}
+
+func (s *Server) routeOrders(r *http.Request, parts []string, body []byte, accountName string) (interface{}, *apiError) {
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		return s.listOrders(accountName, r.URL.Query().Get("market"), true), nil
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		var payload goftx.PlaceOrderPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, &apiError{status: http.StatusBadRequest, message: "Invalid parameter"}
+		}
+		return s.placeOrder(accountName, payload)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		var payload goftx.CancelAllOrdersPayload
+		_ = json.Unmarshal(body, &payload)
+		for _, o := range s.orders {
+			if o.account == accountName && o.Status != goftx.OrderStatusClosed &&
+				(payload.Market == nil || *payload.Market == o.Market) {
+				o.Status = goftx.OrderStatusClosed
+			}
+		}
+		return "Orders queued for cancelation", nil
+	case len(parts) == 2 && parts[1] == "history" && r.Method == http.MethodGet:
+		var result []goftx.Order
+		for _, o := range s.listOrders(accountName, r.URL.Query().Get("market"), false) {
+			if inTimeRange(r, o.CreatedAt) {
+				result = append(result, o)
+			}
+		}
+		return limit(r, len(result), func(n int) interface{} { return result[:n] }), nil
+	}
+
+	if len(parts) < 2 {
+		return nil, &apiError{status: http.StatusNotFound, message: "Not found"}
+	}
+
+	o, apiErr := s.findOrder(parts[1:], accountName)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	last := parts[len(parts)-1]
+
+	switch {
+	case r.Method == http.MethodGet:
+		return o.Order, nil
+	case r.Method == http.MethodDelete:
+		if o.Status == goftx.OrderStatusClosed {
+			return nil, &apiError{status: http.StatusBadRequest, message: "Order already closed"}
+		}
+		o.Status = goftx.OrderStatusClosed
+		return "Order queued for cancellation", nil
+	case r.Method == http.MethodPost && last == "modify":
+		var payload goftx.ModifyOrderPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, &apiError{status: http.StatusBadRequest, message: "Invalid parameter"}
+		}
+		return s.modifyOrder(o, payload)
+	}
+
+	return nil, &apiError{status: http.StatusNotFound, message: "Not found"}
+}
+
+func (s *Server) findOrder(parts []string, accountName string) (*order, *apiError) {
+	if parts[0] == "by_client_id" && len(parts) > 1 {
+		for _, o := range s.orders {
+			if o.account == accountName && o.ClientID == parts[1] {
+				return o, nil
+			}
+		}
+		return nil, &apiError{status: http.StatusNotFound, message: "Order not found"}
+	}
+
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Invalid order id"}
+	}
+	o, ok := s.orders[id]
+	if !ok || o.account != accountName {
+		return nil, &apiError{status: http.StatusNotFound, message: "Order not found"}
+	}
+	return o, nil
+}
+
+func (s *Server) placeOrder(accountName string, payload goftx.PlaceOrderPayload) (interface{}, *apiError) {
+	market, ok := s.markets[payload.Market]
+	if !ok {
+		return nil, &apiError{status: http.StatusBadRequest, message: "No such market: " + payload.Market}
+	}
+	if payload.Side != goftx.SideBuy && payload.Side != goftx.SideSell {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Invalid side"}
+	}
+	if !payload.Size.IsPositive() {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Invalid size"}
+	}
+	if payload.Type == goftx.OrderTypeLimitOrder && !payload.Price.IsPositive() {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Invalid price"}
+	}
+	if payload.ClientID != "" {
+		for _, o := range s.orders {
+			if o.account == accountName && o.ClientID == payload.ClientID && o.Status != goftx.OrderStatusClosed {
+				return nil, &apiError{status: http.StatusBadRequest, message: "Duplicate client order ID"}
+			}
+		}
+	}
+
+	future := ""
+	if market.Type == goftx.MarketTypeFuture {
+		future = market.Name
+	}
+
+	o := &order{
+		account: accountName,
+		Order: goftx.Order{
+			ID:            s.id(),
+			Market:        payload.Market,
+			Type:          payload.Type,
+			Side:          payload.Side,
+			Price:         payload.Price,
+			Size:          payload.Size,
+			RemainingSize: payload.Size,
+			Status:        goftx.OrderStatusOpen,
+			CreatedAt:     s.now().UTC(),
+			ReduceOnly:    payload.ReduceOnly,
+			Ioc:           payload.IOC,
+			PostOnly:      payload.PostOnly,
+			Future:        future,
+			ClientID:      payload.ClientID,
+		},
+	}
+	s.orders[o.ID] = o
+
+	return o.Order, nil
+}
+
+// modifyOrder cancels o and places a replacement with a new id, as the exchange does.
+func (s *Server) modifyOrder(o *order, payload goftx.ModifyOrderPayload) (interface{}, *apiError) {
+	if o.Status == goftx.OrderStatusClosed {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Order already closed"}
+	}
+
+	replacement := *o
+	replacement.ID = s.id()
+	replacement.CreatedAt = s.now().UTC()
+	if payload.Price != nil {
+		replacement.Price = *payload.Price
+	}
+	if payload.Size != nil {
+		if !payload.Size.GreaterThan(o.FilledSize) {
+			return nil, &apiError{status: http.StatusBadRequest, message: "Invalid size"}
+		}
+		replacement.Size = *payload.Size
+	}
+	if payload.ClientID != nil {
+		replacement.ClientID = *payload.ClientID
+	}
+	replacement.FilledSize = decimal.Zero
+	replacement.AvgFillPrice = decimal.Zero
+	replacement.RemainingSize = replacement.Size.Sub(o.FilledSize)
+	replacement.Size = replacement.RemainingSize
+
+	o.Status = goftx.OrderStatusClosed
+	s.orders[replacement.ID] = &replacement
+
+	return replacement.Order, nil
+}
+
+func (s *Server) listOrders(accountName, market string, openOnly bool) []goftx.Order {
+	result := make([]goftx.Order, 0)
+	for _, o := range s.orders {
+		if o.account != accountName || (market != "" && o.Market != market) {
+			continue
+		}
+		if openOnly && o.Status == goftx.OrderStatusClosed {
+			continue
+		}
+		result = append(result, o.Order)
+	}
+
+	// Newest first, like the exchange.
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID > result[j].ID
+	})
+	return result
+}
+
+func (s *Server) getFills(r *http.Request, accountName string) (interface{}, *apiError) {
+	query := r.URL.Query()
+	fills := s.account(accountName).fills
+
+	result := make([]goftx.Fill, 0, len(fills))
+	for i := len(fills) - 1; i >= 0; i-- {
+		fill := fills[i]
+		if market := query.Get("market"); market != "" && fill.Market != market {
+			continue
+		}
+		if orderID := query.Get("orderId"); orderID != "" && strconv.FormatInt(fill.OrderID, 10) != orderID {
+			continue
+		}
+		if !inTimeRange(r, fill.Time.Time) {
+			continue
+		}
+		result = append(result, fill)
+	}
+	if query.Get("order") == "asc" {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+
+	return limit(r, len(result), func(n int) interface{} { return result[:n] }), nil
+}
+
+func (s *Server) getPositions(accountName string) []goftx.Position {
+	result := make([]goftx.Position, 0)
+	for _, position := range s.account(accountName).positions {
+		result = append(result, position)
+	}
+	return result
+}
+
+func (s *Server) getBalances(accountName string) []goftx.Balance {
+	result := make([]goftx.Balance, 0)
+	for _, balance := range s.account(accountName).balances {
+		result = append(result, *balance)
+	}
+	return result
+}
+
+func (s *Server) routeSubAccounts(r *http.Request, parts []string, body []byte) (interface{}, *apiError) {
+	var payload struct {
+		Nickname    string          `json:"nickname"`
+		NewNickname string          `json:"newNickname"`
+		Coin        string          `json:"coin"`
+		Size        decimal.Decimal `json:"size"`
+		Source      *string         `json:"source"`
+		Destination *string         `json:"destination"`
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, &apiError{status: http.StatusBadRequest, message: "Invalid parameter"}
+		}
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		result := make([]goftx.SubAccount, 0, len(s.subAccounts))
+		for _, subAccount := range s.subAccounts {
+			result = append(result, subAccount)
+		}
+		return result, nil
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		if _, ok := s.subAccounts[payload.Nickname]; ok || payload.Nickname == "" {
+			return nil, &apiError{status: http.StatusBadRequest, message: "Nickname already taken"}
+		}
+		subAccount := goftx.SubAccount{Nickname: payload.Nickname, Deletable: true, Editable: true}
+		s.subAccounts[payload.Nickname] = subAccount
+		return subAccount, nil
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if _, ok := s.subAccounts[payload.Nickname]; !ok {
+			return nil, &apiError{status: http.StatusBadRequest, message: "No such subaccount"}
+		}
+		delete(s.subAccounts, payload.Nickname)
+		delete(s.accounts, payload.Nickname)
+		return nil, nil
+	case len(parts) == 2 && parts[1] == "update_name" && r.Method == http.MethodPost:
+		subAccount, ok := s.subAccounts[payload.Nickname]
+		if !ok {
+			return nil, &apiError{status: http.StatusBadRequest, message: "No such subaccount"}
+		}
+		subAccount.Nickname = payload.NewNickname
+		delete(s.subAccounts, payload.Nickname)
+		s.subAccounts[payload.NewNickname] = subAccount
+		if acc, ok := s.accounts[payload.Nickname]; ok {
+			delete(s.accounts, payload.Nickname)
+			s.accounts[payload.NewNickname] = acc
+		}
+		return nil, nil
+	case len(parts) == 3 && parts[2] == "balances" && r.Method == http.MethodGet:
+		if _, ok := s.subAccounts[parts[1]]; !ok {
+			return nil, &apiError{status: http.StatusBadRequest, message: "No such subaccount"}
+		}
+		return s.getBalances(parts[1]), nil
+	case len(parts) == 2 && parts[1] == "transfer" && r.Method == http.MethodPost:
+		return s.transfer(payload.Coin, payload.Size, payload.Source, payload.Destination)
+	}
+
+	return nil, &apiError{status: http.StatusNotFound, message: "Not found"}
+}
+
+func (s *Server) transfer(coin string, size decimal.Decimal, source, destination *string) (interface{}, *apiError) {
+	from, to := mainAccount, mainAccount
+	if source != nil {
+		from = *source
+	}
+	if destination != nil {
+		to = *destination
+	}
+	for _, name := range []string{from, to} {
+		if _, ok := s.subAccounts[name]; name != mainAccount && !ok {
+			return nil, &apiError{status: http.StatusBadRequest, message: "No such subaccount"}
+		}
+	}
+	if from == to {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Cannot transfer to the same account"}
+	}
+	if !size.IsPositive() {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Invalid size"}
+	}
+
+	fromBalance, ok := s.account(from).balances[coin]
+	if !ok || fromBalance.Free.LessThan(size) {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Not enough balances"}
+	}
+	toBalance, ok := s.account(to).balances[coin]
+	if !ok {
+		toBalance = &goftx.Balance{Coin: coin}
+		s.account(to).balances[coin] = toBalance
+	}
+
+	fromBalance.Free = fromBalance.Free.Sub(size)
+	fromBalance.Total = fromBalance.Total.Sub(size)
+	toBalance.Free = toBalance.Free.Add(size)
+	toBalance.Total = toBalance.Total.Add(size)
+
+	return goftx.TransferResponse{
+		ID:     s.id(),
+		Coin:   coin,
+		Size:   size,
+		Time:   s.now().UTC(),
+		Status: goftx.TransferStatusComplete,
+	}, nil
+}
+
+func inTimeRange(r *http.Request, t time.Time) bool {
+	query := r.URL.Query()
+	if start, err := strconv.ParseInt(query.Get("start_time"), 10, 64); err == nil && t.Unix() < start {
+		return false
+	}
+	if end, err := strconv.ParseInt(query.Get("end_time"), 10, 64); err == nil && t.Unix() > end {
+		return false
+	}
+	return true
+}
+
+func limit(r *http.Request, length int, slice func(n int) interface{}) interface{} {
+	n, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || n <= 0 || n > length {
+		n = length
+	}
+	return slice(n)
+}
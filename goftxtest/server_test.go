@@ -0,0 +1,162 @@
+package goftxtest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/wizpacekorea/goftx"
+	"github.com/wizpacekorea/goftx/goftxtest"
+)
+
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func newServer(t *testing.T) *goftxtest.Server {
+	server := goftxtest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.AddMarket(goftx.Market{
+		Name:           "BTC/USD",
+		Type:           goftx.MarketTypeSpot,
+		BaseCurrency:   "BTC",
+		QuoteCurrency:  "USD",
+		PriceIncrement: dec("0.5"),
+		SizeIncrement:  dec("0.0001"),
+	})
+	return server
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	server := newServer(t)
+	client := server.Client()
+
+	order, err := client.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market:   "BTC/USD",
+		Side:     goftx.SideBuy,
+		Type:     goftx.OrderTypeLimitOrder,
+		Price:    dec("30000"),
+		Size:     dec("2"),
+		ClientID: "lifecycle",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != goftx.OrderStatusNew && order.Status != goftx.OrderStatusOpen {
+		t.Fatalf("placed order status = %s", order.Status)
+	}
+
+	price := dec("29999.5")
+	modified, err := client.ModifyOrder(&goftx.ModifyOrderPayload{Price: &price}, order.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if modified.ID == order.ID || !modified.Price.Equal(price) {
+		t.Fatalf("modified order = %+v, want a replacement at %s", modified, price)
+	}
+	if original, _ := server.Order(order.ID); original.Status != goftx.OrderStatusClosed {
+		t.Errorf("original order status = %s, want closed", original.Status)
+	}
+
+	if _, ok := server.FillOrder(modified.ID, dec("0"), price, goftx.LiquidityMaker); ok {
+		t.Fatal("FillOrder filled a size of zero")
+	}
+	fill, ok := server.FillOrder(modified.ID, dec("0.5"), price, goftx.LiquidityMaker)
+	if !ok {
+		t.Fatal("FillOrder did not fill the open order")
+	}
+
+	market := "BTC/USD"
+	fills, err := client.GetFills(&goftx.GetFillsParams{Market: &market})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != 1 || fills[0].ID != fill.ID || fills[0].OrderID != modified.ID || !fills[0].Size.Equal(dec("0.5")) {
+		t.Fatalf("fills = %+v, want the fill of order %d", fills, modified.ID)
+	}
+
+	err = client.CancelOrder(modified.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelled, err := client.GetOrder(modified.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.Status != goftx.OrderStatusClosed || !cancelled.FilledSize.Equal(dec("0.5")) {
+		t.Errorf("cancelled order = %+v, want closed with 0.5 filled", cancelled)
+	}
+
+	err = client.CancelOrder(modified.ID)
+	if !goftx.IsOrderClosed(err) {
+		t.Errorf("second cancel error = %v, want order closed", err)
+	}
+}
+
+func TestSubAccountTransfer(t *testing.T) {
+	server := newServer(t)
+	// The nickname needs escaping in the FTX-SUBACCOUNT header.
+	nickname := "paper/ünit test"
+	server.AddSubAccount(nickname)
+	server.SetBalance("", goftx.Balance{Coin: "USD", Free: dec("1000"), Total: dec("1000")})
+
+	client := server.Client()
+	_, err := client.Transfer(&goftx.TransferPayload{
+		Coin:        "USD",
+		Size:        dec("250"),
+		Destination: &nickname,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance := server.Balance("", "USD"); !balance.Total.Equal(dec("750")) {
+		t.Errorf("main USD = %s, want 750", balance.Total)
+	}
+
+	subClient := server.SubAccountClient(nickname)
+	balances, err := subClient.GetWalletBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 1 || balances[0].Coin != "USD" || !balances[0].Total.Equal(dec("250")) {
+		t.Fatalf("subaccount balances = %+v, want 250 USD", balances)
+	}
+
+	order, err := subClient.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market: "BTC/USD",
+		Side:   goftx.SideBuy,
+		Type:   goftx.OrderTypeLimitOrder,
+		Price:  dec("100"),
+		Size:   dec("1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetOrder(order.ID); !goftx.IsOrderNotFound(err) {
+		t.Errorf("main account read the subaccount order, error = %v", err)
+	}
+}
+
+func TestBadSignature(t *testing.T) {
+	server := newServer(t)
+	client := goftx.New(
+		goftx.WithHTTPClient(server.Server.Client()),
+		goftx.WithBaseURL(server.APIURL()),
+		goftx.WithAuth(goftxtest.DefaultAPIKey, "wrong-secret"),
+		goftx.WithRateLimiter(nil),
+	)
+
+	_, err := client.GetOpenOrders("BTC/USD")
+	var apiErr *goftx.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("error = %v, want 401", err)
+	}
+
+	// Public endpoints are not signed.
+	if _, err := client.GetMarketByName("BTC/USD"); err != nil {
+		t.Fatal(err)
+	}
+}
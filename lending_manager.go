@@ -0,0 +1,282 @@
+package goftx
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultLendingInterval     = time.Hour
+	DefaultLendingIncomeWindow = 24 * time.Hour
+)
+
+// DefaultLendingSizeIncrement is the precision offered sizes are rounded down to.
+var DefaultLendingSizeIncrement = decimal.New(1, -8)
+
+// LendingPolicy decides the minimum hourly rate a coin is offered at.
+// history only holds the lending history of coin.
+type LendingPolicy interface {
+	MinRate(coin string, rate LendingRate, history []LendingHistory) float64
+}
+
+type LendingPolicyFunc func(coin string, rate LendingRate, history []LendingHistory) float64
+
+func (f LendingPolicyFunc) MinRate(coin string, rate LendingRate, history []LendingHistory) float64 {
+	return f(coin, rate, history)
+}
+
+// FixedLendingRate offers every coin at the same rate.
+type FixedLendingRate float64
+
+func (r FixedLendingRate) MinRate(string, LendingRate, []LendingHistory) float64 {
+	return float64(r)
+}
+
+// PercentileLendingRate offers at the given percentile (0-100) of the rates realised within Window,
+// coins without history are offered at the estimated rate.
+type PercentileLendingRate struct {
+	Percentile float64
+	Window     time.Duration
+}
+
+func (p PercentileLendingRate) MinRate(_ string, rate LendingRate, history []LendingHistory) float64 {
+	since := time.Now().Add(-p.Window)
+	var rates []float64
+	for _, entry := range history {
+		if p.Window > 0 && entry.Time.Before(since) {
+			continue
+		}
+		value, _ := entry.Rate.Float64()
+		rates = append(rates, value)
+	}
+	if len(rates) == 0 {
+		return rate.Estimate
+	}
+
+	sort.Float64s(rates)
+	rank := int(math.Ceil(p.Percentile/100*float64(len(rates)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(rates) {
+		rank = len(rates) - 1
+	}
+	return rates[rank]
+}
+
+// EstimateMinusMarginRate offers below the estimated rate of the next hour, but never below Floor.
+type EstimateMinusMarginRate struct {
+	Margin float64
+	Floor  float64
+}
+
+func (e EstimateMinusMarginRate) MinRate(_ string, rate LendingRate, _ []LendingHistory) float64 {
+	return math.Max(rate.Estimate-e.Margin, e.Floor)
+}
+
+// LendingChange is an offer replaced by the manager.
+type LendingChange struct {
+	Coin    string
+	OldSize decimal.Decimal
+	NewSize decimal.Decimal
+	OldRate float64
+	NewRate float64
+}
+
+// LendingIncome is the income realised by a coin since Since.
+type LendingIncome struct {
+	Coin   string
+	Income decimal.Decimal
+	Since  time.Time
+}
+
+type LendingReport struct {
+	Time    time.Time
+	Changes []LendingChange
+	Income  []LendingIncome
+}
+
+// LendingManager keeps lending offers in line with a LendingPolicy,
+// it offers a fraction of the lendable balance of every coin (or the configured coins).
+type LendingManager struct {
+	spotMargin   *SpotMargin
+	policy       LendingPolicy
+	fraction     decimal.Decimal
+	increment    decimal.Decimal
+	coins        map[string]struct{}
+	interval     time.Duration
+	incomeWindow time.Duration
+	errorHandler func(error)
+}
+
+func (s *SpotMargin) NewLendingManager(policy LendingPolicy) *LendingManager {
+	return &LendingManager{
+		spotMargin:   s,
+		policy:       policy,
+		fraction:     decimal.NewFromInt(1),
+		increment:    DefaultLendingSizeIncrement,
+		interval:     DefaultLendingInterval,
+		incomeWindow: DefaultLendingIncomeWindow,
+	}
+}
+
+// SetFraction sets the part of the lendable balance that is offered, 1 offers everything.
+func (m *LendingManager) SetFraction(fraction decimal.Decimal) {
+	m.fraction = fraction
+}
+
+// SetSizeIncrement sets the increment offered sizes are rounded down to.
+func (m *LendingManager) SetSizeIncrement(increment decimal.Decimal) {
+	m.increment = increment
+}
+
+// SetCoins restricts the manager to coins, no coins manages every lendable coin.
+func (m *LendingManager) SetCoins(coins ...string) {
+	m.coins = make(map[string]struct{}, len(coins))
+	for _, coin := range coins {
+		m.coins[coin] = struct{}{}
+	}
+}
+
+func (m *LendingManager) SetInterval(interval time.Duration) {
+	m.interval = interval
+}
+
+// SetIncomeWindow sets how far back reports sum the realised income.
+func (m *LendingManager) SetIncomeWindow(window time.Duration) {
+	m.incomeWindow = window
+}
+
+// SetErrorHandler registers a callback for failed rebalances of Run, the manager retries on the next tick.
+func (m *LendingManager) SetErrorHandler(handler func(error)) {
+	m.errorHandler = handler
+}
+
+// Run rebalances immediately and then every interval until ctx is done, a report is sent for every successful pass.
+func (m *LendingManager) Run(ctx context.Context) <-chan *LendingReport {
+	reports := make(chan *LendingReport)
+
+	go func() {
+		defer close(reports)
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			report, err := m.Rebalance(ctx)
+			if err != nil {
+				if m.errorHandler != nil {
+					m.errorHandler(err)
+				}
+			} else {
+				select {
+				case reports <- report:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return reports
+}
+
+// Rebalance replaces every offer whose size or rate differs from the policy and reports the realised income.
+func (m *LendingManager) Rebalance(ctx context.Context) (*LendingReport, error) {
+	infos, err := m.spotMargin.GetLendingInfoWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rates, err := m.spotMargin.GetLendingRatesWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	ratesByCoin := make(map[string]LendingRate, len(rates))
+	for _, rate := range rates {
+		ratesByCoin[rate.Coin] = rate
+	}
+
+	history, err := m.spotMargin.GetLendingHistoryWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	historyByCoin := make(map[string][]LendingHistory)
+	for _, entry := range history {
+		historyByCoin[entry.Coin] = append(historyByCoin[entry.Coin], entry)
+	}
+
+	report := &LendingReport{Time: time.Now()}
+	for _, info := range infos {
+		if !m.manages(info.Coin) {
+			continue
+		}
+
+		size := RoundToIncrement(info.Lendable.Mul(m.fraction), m.increment, RoundDown)
+		rate := m.policy.MinRate(info.Coin, ratesByCoin[info.Coin], historyByCoin[info.Coin])
+		if size.Equal(info.Offered) && (size.IsZero() || sameLendingRate(rate, info.MinRate)) {
+			continue
+		}
+
+		err = m.spotMargin.SubmitLendingOfferWithContext(ctx, &LendingOfferPayload{
+			Coin: info.Coin,
+			Size: size,
+			Rate: rate,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "offer %s", info.Coin)
+		}
+
+		report.Changes = append(report.Changes, LendingChange{
+			Coin:    info.Coin,
+			OldSize: info.Offered,
+			NewSize: size,
+			OldRate: info.MinRate,
+			NewRate: rate,
+		})
+	}
+
+	since := report.Time.Add(-m.incomeWindow)
+	for coin, entries := range historyByCoin {
+		if !m.manages(coin) {
+			continue
+		}
+
+		income := LendingIncome{Coin: coin, Since: since}
+		for _, entry := range entries {
+			if !entry.Time.Before(since) {
+				income.Income = income.Income.Add(entry.Proceeds)
+			}
+		}
+		report.Income = append(report.Income, income)
+	}
+	sort.Slice(report.Income, func(i, j int) bool {
+		return report.Income[i].Coin < report.Income[j].Coin
+	})
+
+	return report, nil
+}
+
+func (m *LendingManager) manages(coin string) bool {
+	if len(m.coins) == 0 {
+		return true
+	}
+	_, ok := m.coins[coin]
+	return ok
+}
+
+// Rates are hourly and tiny, they are compared with a tolerance below what the exchange displays.
+func sameLendingRate(a, b float64) bool {
+	return math.Abs(a-b) < 1e-10
+}
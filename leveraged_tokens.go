@@ -0,0 +1,324 @@
+package goftx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type LeveragedToken struct {
+	Name              string                     `json:"name"`
+	Description       string                     `json:"description"`
+	Underlying        string                     `json:"underlying"`
+	Leverage          decimal.Decimal            `json:"leverage"`
+	Outstanding       decimal.Decimal            `json:"outstanding"`
+	PricePerShare     decimal.Decimal            `json:"pricePerShare"`
+	PositionPerShare  decimal.Decimal            `json:"positionPerShare"`
+	PositionsPerShare map[string]decimal.Decimal `json:"positionsPerShare"`
+	Basket            map[string]decimal.Decimal `json:"basket"`
+	TargetComponents  []string                   `json:"targetComponents"`
+	UnderlyingMark    decimal.Decimal            `json:"underlyingMark"`
+	TotalNav          decimal.Decimal            `json:"totalNav"`
+	TotalCollateral   decimal.Decimal            `json:"totalCollateral"`
+	CurrentLeverage   decimal.Decimal            `json:"currentLeverage"`
+	ContractAddress   string                     `json:"contractAddress"`
+	Change1h          decimal.Decimal            `json:"change1h"`
+	Change24h         decimal.Decimal            `json:"change24h"`
+	ChangeBod         decimal.Decimal            `json:"changeBod"`
+}
+
+type LeveragedTokenBalance struct {
+	Token   string          `json:"token"`
+	Balance decimal.Decimal `json:"balance"`
+}
+
+type LeveragedTokenCreation struct {
+	ID            int64           `json:"id"`
+	Token         string          `json:"token"`
+	RequestedSize decimal.Decimal `json:"requestedSize"`
+	CreatedSize   decimal.Decimal `json:"createdSize"`
+	Pending       bool            `json:"pending"`
+	Price         decimal.Decimal `json:"price"`
+	Cost          decimal.Decimal `json:"cost"`
+	Fee           decimal.Decimal `json:"fee"`
+	RequestedAt   time.Time       `json:"requestedAt"`
+	FulfilledAt   time.Time       `json:"fulfilledAt"`
+}
+
+type LeveragedTokenRedemption struct {
+	ID                int64           `json:"id"`
+	Token             string          `json:"token"`
+	Size              decimal.Decimal `json:"size"`
+	Pending           bool            `json:"pending"`
+	Price             decimal.Decimal `json:"price"`
+	ProjectedProceeds decimal.Decimal `json:"projectedProceeds"`
+	Proceeds          decimal.Decimal `json:"proceeds"`
+	Fee               decimal.Decimal `json:"fee"`
+	RequestedAt       time.Time       `json:"requestedAt"`
+	FulfilledAt       time.Time       `json:"fulfilledAt"`
+}
+
+// ETFRebalanceInfo is the last rebalance of a leveraged token.
+type ETFRebalanceInfo struct {
+	OrderSizeList []decimal.Decimal `json:"orderSizeList"`
+	Side          string            `json:"side"`
+	Time          string            `json:"time"`
+}
+
+const (
+	apiLeveragedTokens           = "/lt/tokens"
+	apiLeveragedToken            = "/lt/%s"
+	apiLeveragedTokenBalances    = "/lt/balances"
+	apiLeveragedTokenCreations   = "/lt/creations"
+	apiCreateLeveragedToken      = "/lt/%s/create"
+	apiLeveragedTokenRedemptions = "/lt/redemptions"
+	apiRedeemLeveragedToken      = "/lt/%s/redeem"
+	apiETFRebalanceInfo          = "/etfs/rebalance_info"
+)
+
+type LeveragedTokens struct {
+	client *Client
+}
+
+func (l *LeveragedTokens) GetLeveragedTokens() ([]LeveragedToken, error) {
+	return l.GetLeveragedTokensWithContext(context.Background())
+}
+
+func (l *LeveragedTokens) GetLeveragedTokensWithContext(ctx context.Context) ([]LeveragedToken, error) {
+	request, err := l.client.prepareRequest(ctx, Request{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", l.client.apiUrl, apiLeveragedTokens),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := l.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []LeveragedToken
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (l *LeveragedTokens) GetLeveragedToken(name string) (*LeveragedToken, error) {
+	return l.GetLeveragedTokenWithContext(context.Background(), name)
+}
+
+func (l *LeveragedTokens) GetLeveragedTokenWithContext(ctx context.Context, name string) (*LeveragedToken, error) {
+	request, err := l.client.prepareRequest(ctx, Request{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", l.client.apiUrl, fmt.Sprintf(apiLeveragedToken, name)),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := l.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *LeveragedToken
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (l *LeveragedTokens) GetLeveragedTokenBalances() ([]LeveragedTokenBalance, error) {
+	return l.GetLeveragedTokenBalancesWithContext(context.Background())
+}
+
+func (l *LeveragedTokens) GetLeveragedTokenBalancesWithContext(ctx context.Context) ([]LeveragedTokenBalance, error) {
+	request, err := l.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", l.client.apiUrl, apiLeveragedTokenBalances),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := l.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []LeveragedTokenBalance
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (l *LeveragedTokens) GetLeveragedTokenCreations() ([]LeveragedTokenCreation, error) {
+	return l.GetLeveragedTokenCreationsWithContext(context.Background())
+}
+
+func (l *LeveragedTokens) GetLeveragedTokenCreationsWithContext(ctx context.Context) ([]LeveragedTokenCreation, error) {
+	request, err := l.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", l.client.apiUrl, apiLeveragedTokenCreations),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := l.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []LeveragedTokenCreation
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (l *LeveragedTokens) CreateLeveragedToken(name string, size decimal.Decimal) (*LeveragedTokenCreation, error) {
+	return l.CreateLeveragedTokenWithContext(context.Background(), name, size)
+}
+
+func (l *LeveragedTokens) CreateLeveragedTokenWithContext(ctx context.Context, name string, size decimal.Decimal) (*LeveragedTokenCreation, error) {
+	body, err := json.Marshal(struct {
+		Size decimal.Decimal `json:"size"`
+	}{Size: size})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := l.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s%s", l.client.apiUrl, fmt.Sprintf(apiCreateLeveragedToken, name)),
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := l.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *LeveragedTokenCreation
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (l *LeveragedTokens) GetLeveragedTokenRedemptions() ([]LeveragedTokenRedemption, error) {
+	return l.GetLeveragedTokenRedemptionsWithContext(context.Background())
+}
+
+func (l *LeveragedTokens) GetLeveragedTokenRedemptionsWithContext(ctx context.Context) ([]LeveragedTokenRedemption, error) {
+	request, err := l.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", l.client.apiUrl, apiLeveragedTokenRedemptions),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := l.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []LeveragedTokenRedemption
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (l *LeveragedTokens) RedeemLeveragedToken(name string, size decimal.Decimal) (*LeveragedTokenRedemption, error) {
+	return l.RedeemLeveragedTokenWithContext(context.Background(), name, size)
+}
+
+func (l *LeveragedTokens) RedeemLeveragedTokenWithContext(ctx context.Context, name string, size decimal.Decimal) (*LeveragedTokenRedemption, error) {
+	body, err := json.Marshal(struct {
+		Size decimal.Decimal `json:"size"`
+	}{Size: size})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := l.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s%s", l.client.apiUrl, fmt.Sprintf(apiRedeemLeveragedToken, name)),
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := l.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *LeveragedTokenRedemption
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+// GetETFRebalanceInfo returns the last rebalance of every leveraged token by token name.
+func (l *LeveragedTokens) GetETFRebalanceInfo() (map[string]ETFRebalanceInfo, error) {
+	return l.GetETFRebalanceInfoWithContext(context.Background())
+}
+
+func (l *LeveragedTokens) GetETFRebalanceInfoWithContext(ctx context.Context) (map[string]ETFRebalanceInfo, error) {
+	request, err := l.client.prepareRequest(ctx, Request{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", l.client.apiUrl, apiETFRebalanceInfo),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := l.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result map[string]ETFRebalanceInfo
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,282 @@
+package goftx
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const DefaultMarketRegistryTTL = 5 * time.Minute
+
+var ErrUnknownMarket = errors.New("unknown market")
+
+// MarketDiff lists the markets added, removed or changed by a refresh of a MarketRegistry.
+// Prices and volumes are ignored, a market is changed when its metadata differs.
+type MarketDiff struct {
+	Added   []Market
+	Removed []Market
+	Changed []MarketChange
+}
+
+type MarketChange struct {
+	Old Market
+	New Market
+}
+
+func (d *MarketDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// MarketRegistry caches the metadata of all markets and futures.
+// Lookups load the registry on first use and reload it once the TTL has expired, a zero TTL never expires.
+type MarketRegistry struct {
+	client   *Client
+	ttl      time.Duration
+	onChange func(MarketDiff)
+
+	refreshMu sync.Mutex
+	mu        sync.RWMutex
+	loadedAt  time.Time
+	markets   map[string]Market
+	futures   map[string]Future
+}
+
+// WithMarketRegistry replaces the default registry of the client, e.g. to share one between clients.
+func WithMarketRegistry(registry *MarketRegistry) Option {
+	return func(c *Client) {
+		c.marketRegistry = registry
+	}
+}
+
+// MarketRegistry returns the registry used by the client, it expires after DefaultMarketRegistryTTL unless replaced.
+func (c *Client) MarketRegistry() *MarketRegistry {
+	return c.marketRegistry
+}
+
+func NewMarketRegistry(client *Client, ttl time.Duration) *MarketRegistry {
+	return &MarketRegistry{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// SetChangeHandler sets a function called with the diff of every refresh which changed anything.
+// The first load reports every market as added.
+func (r *MarketRegistry) SetChangeHandler(handler func(MarketDiff)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onChange = handler
+}
+
+// Refresh reloads markets and futures and returns what changed since the previous load.
+func (r *MarketRegistry) Refresh(ctx context.Context) (*MarketDiff, error) {
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	return r.refresh(ctx)
+}
+
+func (r *MarketRegistry) refresh(ctx context.Context) (*MarketDiff, error) {
+	markets, err := r.client.Markets.GetMarketsWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	futures, err := r.client.Futures.GetFuturesWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	marketsByName := make(map[string]Market, len(markets))
+	for _, market := range markets {
+		marketsByName[market.Name] = market
+	}
+	futuresByName := make(map[string]Future, len(futures))
+	for _, future := range futures {
+		futuresByName[future.Name] = future
+	}
+
+	r.mu.Lock()
+	diff := diffMarkets(r.markets, marketsByName)
+	r.markets = marketsByName
+	r.futures = futuresByName
+	r.loadedAt = time.Now()
+	onChange := r.onChange
+	r.mu.Unlock()
+
+	if onChange != nil && !diff.Empty() {
+		onChange(*diff)
+	}
+
+	return diff, nil
+}
+
+func (r *MarketRegistry) ensureLoaded(ctx context.Context) error {
+	if !r.expired() {
+		return nil
+	}
+
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	// Another goroutine may have refreshed while we were waiting.
+	if !r.expired() {
+		return nil
+	}
+
+	_, err := r.refresh(ctx)
+	return errors.WithStack(err)
+}
+
+func (r *MarketRegistry) expired() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.markets == nil {
+		return true
+	}
+	return r.ttl > 0 && time.Since(r.loadedAt) > r.ttl
+}
+
+func (r *MarketRegistry) Market(ctx context.Context, name string) (*Market, error) {
+	err := r.ensureLoaded(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	market, ok := r.markets[name]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownMarket, name)
+	}
+	return &market, nil
+}
+
+func (r *MarketRegistry) Future(ctx context.Context, name string) (*Future, error) {
+	err := r.ensureLoaded(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	future, ok := r.futures[name]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownMarket, name)
+	}
+	return &future, nil
+}
+
+func (r *MarketRegistry) Increments(ctx context.Context, name string) (OrderIncrements, error) {
+	market, err := r.Market(ctx, name)
+	if err != nil {
+		return OrderIncrements{}, errors.WithStack(err)
+	}
+	return MarketIncrements(market), nil
+}
+
+// Markets returns all markets sorted by name.
+func (r *MarketRegistry) Markets(ctx context.Context) ([]Market, error) {
+	return r.filterMarkets(ctx, func(Market) bool { return true })
+}
+
+// MarketsByCurrency returns the spot markets trading base against quote, an empty currency matches any.
+func (r *MarketRegistry) MarketsByCurrency(ctx context.Context, base, quote string) ([]Market, error) {
+	return r.filterMarkets(ctx, func(market Market) bool {
+		return market.Type == MarketTypeSpot &&
+			(base == "" || market.BaseCurrency == base) &&
+			(quote == "" || market.QuoteCurrency == quote)
+	})
+}
+
+// MarketsByUnderlying returns the futures markets of underlying.
+func (r *MarketRegistry) MarketsByUnderlying(ctx context.Context, underlying string) ([]Market, error) {
+	return r.filterMarkets(ctx, func(market Market) bool {
+		return market.Type == MarketTypeFuture && market.Underlying == underlying
+	})
+}
+
+// MarketsByType returns markets of marketType, MarketTypeSpot or MarketTypeFuture which matches every futures market.
+func (r *MarketRegistry) MarketsByType(ctx context.Context, marketType string) ([]Market, error) {
+	return r.filterMarkets(ctx, func(market Market) bool {
+		return market.Type == marketType
+	})
+}
+
+// FuturesByType returns the futures markets of futureType, one of FutureTypeFuture (dated futures),
+// FutureTypePerpetual or FutureTypeMove.
+func (r *MarketRegistry) FuturesByType(ctx context.Context, futureType string) ([]Market, error) {
+	err := r.ensureLoaded(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	r.mu.RLock()
+	futures := r.futures
+	r.mu.RUnlock()
+
+	return r.filterMarkets(ctx, func(market Market) bool {
+		future, ok := futures[market.Name]
+		return market.Type == MarketTypeFuture && ok && future.Type == futureType
+	})
+}
+
+func (r *MarketRegistry) filterMarkets(ctx context.Context, match func(Market) bool) ([]Market, error) {
+	err := r.ensureLoaded(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []Market
+	for _, market := range r.markets {
+		if match(market) {
+			result = append(result, market)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+func diffMarkets(old, new map[string]Market) *MarketDiff {
+	diff := &MarketDiff{}
+	for name, market := range new {
+		previous, ok := old[name]
+		if !ok {
+			diff.Added = append(diff.Added, market)
+		} else if !sameMarketMetadata(previous, market) {
+			diff.Changed = append(diff.Changed, MarketChange{Old: previous, New: market})
+		}
+	}
+	for name, market := range old {
+		if _, ok := new[name]; !ok {
+			diff.Removed = append(diff.Removed, market)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].New.Name < diff.Changed[j].New.Name })
+
+	return diff
+}
+
+func sameMarketMetadata(a, b Market) bool {
+	return a.Type == b.Type &&
+		a.Underlying == b.Underlying &&
+		a.BaseCurrency == b.BaseCurrency &&
+		a.QuoteCurrency == b.QuoteCurrency &&
+		a.Enabled == b.Enabled &&
+		a.PostOnly == b.PostOnly &&
+		a.Restricted == b.Restricted &&
+		a.HighLeverageFeeExempt == b.HighLeverageFeeExempt &&
+		a.PriceIncrement.Equal(b.PriceIncrement) &&
+		a.SizeIncrement.Equal(b.SizeIncrement) &&
+		a.MinProvideSize.Equal(b.MinProvideSize)
+}
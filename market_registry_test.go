@@ -0,0 +1,57 @@
+package goftx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wizpacekorea/goftx"
+	"github.com/wizpacekorea/goftx/goftxtest"
+)
+
+func assertNames(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("markets = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("markets = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMarketRegistryByType(t *testing.T) {
+	server := goftxtest.NewServer()
+	defer server.Close()
+	server.AddMarket(goftx.Market{Name: "BTC/USD", Type: goftx.MarketTypeSpot})
+	server.AddMarket(goftx.Market{Name: "BTC-PERP", Type: goftx.MarketTypeFuture, Underlying: "BTC"})
+	server.AddMarket(goftx.Market{Name: "BTC-0625", Type: goftx.MarketTypeFuture, Underlying: "BTC"})
+	server.AddFuture(goftx.Future{Name: "BTC-PERP", Type: goftx.FutureTypePerpetual, Underlying: "BTC"})
+	server.AddFuture(goftx.Future{Name: "BTC-0625", Type: goftx.FutureTypeFuture, Underlying: "BTC"})
+
+	registry := server.Client().MarketRegistry()
+	ctx := context.Background()
+	marketNames := func(markets []goftx.Market, err error) []string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := make([]string, 0, len(markets))
+		for _, market := range markets {
+			names = append(names, market.Name)
+		}
+		return names
+	}
+
+	names := marketNames(registry.MarketsByType(ctx, goftx.MarketTypeFuture))
+	assertNames(t, names, "BTC-0625", "BTC-PERP")
+	names = marketNames(registry.MarketsByType(ctx, goftx.MarketTypeSpot))
+	assertNames(t, names, "BTC/USD")
+
+	names = marketNames(registry.FuturesByType(ctx, goftx.FutureTypeFuture))
+	assertNames(t, names, "BTC-0625")
+	names = marketNames(registry.FuturesByType(ctx, goftx.FutureTypePerpetual))
+	assertNames(t, names, "BTC-PERP")
+	names = marketNames(registry.FuturesByType(ctx, goftx.FutureTypeMove))
+	assertNames(t, names)
+}
@@ -1,6 +1,7 @@
 package goftx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -119,9 +120,13 @@ type Markets struct {
 }
 
 func (m *Markets) GetMarkets() ([]Market, error) {
-	request, err := m.client.prepareRequest(Request{
+	return m.GetMarketsWithContext(context.Background())
+}
+
+func (m *Markets) GetMarketsWithContext(ctx context.Context) ([]Market, error) {
+	request, err := m.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiGetMarkets),
+		URL:    fmt.Sprintf("%s%s", m.client.apiUrl, apiGetMarkets),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -142,9 +147,13 @@ func (m *Markets) GetMarkets() ([]Market, error) {
 }
 
 func (m *Markets) GetMarketByName(name string) (*Market, error) {
-	request, err := m.client.prepareRequest(Request{
+	return m.GetMarketByNameWithContext(context.Background(), name)
+}
+
+func (m *Markets) GetMarketByNameWithContext(ctx context.Context, name string) (*Market, error) {
+	request, err := m.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s/%s", apiUrl, apiGetMarkets, name),
+		URL:    fmt.Sprintf("%s%s/%s", m.client.apiUrl, apiGetMarkets, name),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -165,6 +174,10 @@ func (m *Markets) GetMarketByName(name string) (*Market, error) {
 }
 
 func (m *Markets) GetOrderBook(marketName string, depth *int) (*OrderBook, error) {
+	return m.GetOrderBookWithContext(context.Background(), marketName, depth)
+}
+
+func (m *Markets) GetOrderBookWithContext(ctx context.Context, marketName string, depth *int) (*OrderBook, error) {
 	params := map[string]string{}
 	if depth != nil {
 		params["depth"] = fmt.Sprintf("%d", *depth)
@@ -172,9 +185,9 @@ func (m *Markets) GetOrderBook(marketName string, depth *int) (*OrderBook, error
 
 	path := fmt.Sprintf(apiGetOrderBook, marketName)
 
-	request, err := m.client.prepareRequest(Request{
+	request, err := m.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, path),
+		URL:    fmt.Sprintf("%s%s", m.client.apiUrl, path),
 		Params: params,
 	})
 	if err != nil {
@@ -196,15 +209,19 @@ func (m *Markets) GetOrderBook(marketName string, depth *int) (*OrderBook, error
 }
 
 func (m *Markets) GetTrades(marketName string, params *GetTradesParams) ([]Trade, error) {
+	return m.GetTradesWithContext(context.Background(), marketName, params)
+}
+
+func (m *Markets) GetTradesWithContext(ctx context.Context, marketName string, params *GetTradesParams) ([]Trade, error) {
 	queryParams, err := PrepareQueryParams(params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
 	path := fmt.Sprintf(apiGetTrades, marketName)
-	request, err := m.client.prepareRequest(Request{
+	request, err := m.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, path),
+		URL:    fmt.Sprintf("%s%s", m.client.apiUrl, path),
 		Params: queryParams,
 	})
 	if err != nil {
@@ -226,15 +243,19 @@ func (m *Markets) GetTrades(marketName string, params *GetTradesParams) ([]Trade
 }
 
 func (m *Markets) GetHistoricalPrices(marketName string, params *GetHistoricalPricesParams) ([]HistoricalPrice, error) {
+	return m.GetHistoricalPricesWithContext(context.Background(), marketName, params)
+}
+
+func (m *Markets) GetHistoricalPricesWithContext(ctx context.Context, marketName string, params *GetHistoricalPricesParams) ([]HistoricalPrice, error) {
 	queryParams, err := PrepareQueryParams(params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
 	path := fmt.Sprintf(apiGetHistoricalPrices, marketName)
-	request, err := m.client.prepareRequest(Request{
+	request, err := m.client.prepareRequest(ctx, Request{
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, path),
+		URL:    fmt.Sprintf("%s%s", m.client.apiUrl, path),
 		Params: queryParams,
 	})
 	if err != nil {
@@ -0,0 +1,552 @@
+package goftx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	OptionTypeCall = "call"
+	OptionTypePut  = "put"
+)
+
+type OptionContract struct {
+	Underlying string          `json:"underlying"`
+	Type       string          `json:"type"`
+	Strike     decimal.Decimal `json:"strike"`
+	Expiry     time.Time       `json:"expiry"`
+}
+
+type OptionQuoteRequest struct {
+	ID             int64           `json:"id"`
+	Option         OptionContract  `json:"option"`
+	Side           string          `json:"side"`
+	Size           decimal.Decimal `json:"size"`
+	Status         string          `json:"status"`
+	Time           time.Time       `json:"time"`
+	RequestExpiry  time.Time       `json:"requestExpiry"`
+	LimitPrice     decimal.Decimal `json:"limitPrice"`
+	HideLimitPrice bool            `json:"hideLimitPrice"`
+	Quotes         []OptionQuote   `json:"quotes"`
+}
+
+type OptionQuote struct {
+	ID          int64           `json:"id"`
+	RequestID   int64           `json:"requestId"`
+	Option      OptionContract  `json:"option"`
+	Price       decimal.Decimal `json:"price"`
+	Size        decimal.Decimal `json:"size"`
+	Collateral  decimal.Decimal `json:"collateral"`
+	QuoterSide  string          `json:"quoterSide"`
+	RequestSide string          `json:"requestSide"`
+	Status      string          `json:"status"`
+	Time        time.Time       `json:"time"`
+	QuoteExpiry time.Time       `json:"quoteExpiry"`
+}
+
+type CreateOptionQuoteRequestPayload struct {
+	Underlying     string           `json:"underlying"`
+	Type           string           `json:"type"`
+	Strike         decimal.Decimal  `json:"strike"`
+	Expiry         int64            `json:"expiry"`
+	Side           string           `json:"side"`
+	Size           decimal.Decimal  `json:"size"`
+	LimitPrice     *decimal.Decimal `json:"limitPrice,omitempty"`
+	HideLimitPrice bool             `json:"hideLimitPrice"`
+	RequestExpiry  *int64           `json:"requestExpiry,omitempty"`
+	CounterpartyID *int64           `json:"counterpartyId,omitempty"`
+}
+
+type OptionsAccountInfo struct {
+	USDBalance                   decimal.Decimal `json:"usdBalance"`
+	LiquidationPrice             decimal.Decimal `json:"liquidationPrice"`
+	Liquidating                  bool            `json:"liquidating"`
+	InitialMarginRequirement     decimal.Decimal `json:"initialMarginRequirement"`
+	MaintenanceMarginRequirement decimal.Decimal `json:"maintenanceMarginRequirement"`
+}
+
+type OptionPosition struct {
+	Option     OptionContract  `json:"option"`
+	Side       string          `json:"side"`
+	Size       decimal.Decimal `json:"size"`
+	NetSize    decimal.Decimal `json:"netSize"`
+	EntryPrice decimal.Decimal `json:"entryPrice"`
+}
+
+type OptionTrade struct {
+	ID     int64           `json:"id"`
+	Option OptionContract  `json:"option"`
+	Price  decimal.Decimal `json:"price"`
+	Size   decimal.Decimal `json:"size"`
+	Time   time.Time       `json:"time"`
+}
+
+type OptionFill struct {
+	ID        int64           `json:"id"`
+	QuoteID   int64           `json:"quoteId"`
+	Option    OptionContract  `json:"option"`
+	Side      string          `json:"side"`
+	Price     decimal.Decimal `json:"price"`
+	Size      decimal.Decimal `json:"size"`
+	Fee       decimal.Decimal `json:"fee"`
+	FeeRate   decimal.Decimal `json:"feeRate"`
+	Liquidity string          `json:"liquidity"`
+	Time      time.Time       `json:"time"`
+}
+
+type GetOptionsHistoryParams struct {
+	StartTime *int `json:"start_time"`
+	EndTime   *int `json:"end_time"`
+	Limit     *int `json:"limit"`
+}
+
+type OptionsVolume struct {
+	Contracts       decimal.Decimal `json:"contracts"`
+	UnderlyingTotal decimal.Decimal `json:"underlying_total"`
+}
+
+const (
+	apiQuoteRequests      = "/options/requests"
+	apiMyQuoteRequests    = "/options/my_requests"
+	apiQuoteRequest       = "/options/requests/%d"
+	apiQuoteRequestQuotes = "/options/requests/%d/quotes"
+	apiMyOptionQuotes     = "/options/my_quotes"
+	apiOptionQuote        = "/options/quotes/%d"
+	apiAcceptOptionQuote  = "/options/quotes/%d/accept"
+	apiOptionsAccountInfo = "/options/account_info"
+	apiOptionPositions    = "/options/positions"
+	apiOptionTrades       = "/options/trades"
+	apiOptionFills        = "/options/fills"
+	apiOptionsVolume      = "/stats/24h_options_volume"
+)
+
+type Options struct {
+	client *Client
+}
+
+// GetOptionQuoteRequests returns the open quote requests of all users.
+func (o *Options) GetOptionQuoteRequests() ([]OptionQuoteRequest, error) {
+	return o.GetOptionQuoteRequestsWithContext(context.Background())
+}
+
+func (o *Options) GetOptionQuoteRequestsWithContext(ctx context.Context) ([]OptionQuoteRequest, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiQuoteRequests),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []OptionQuoteRequest
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) GetMyOptionQuoteRequests() ([]OptionQuoteRequest, error) {
+	return o.GetMyOptionQuoteRequestsWithContext(context.Background())
+}
+
+func (o *Options) GetMyOptionQuoteRequestsWithContext(ctx context.Context) ([]OptionQuoteRequest, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiMyQuoteRequests),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []OptionQuoteRequest
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) CreateOptionQuoteRequest(payload *CreateOptionQuoteRequestPayload) (*OptionQuoteRequest, error) {
+	return o.CreateOptionQuoteRequestWithContext(context.Background(), payload)
+}
+
+func (o *Options) CreateOptionQuoteRequestWithContext(ctx context.Context, payload *CreateOptionQuoteRequestPayload) (*OptionQuoteRequest, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiQuoteRequests),
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *OptionQuoteRequest
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) CancelOptionQuoteRequest(requestID int64) (*OptionQuoteRequest, error) {
+	return o.CancelOptionQuoteRequestWithContext(context.Background(), requestID)
+}
+
+func (o *Options) CancelOptionQuoteRequestWithContext(ctx context.Context, requestID int64) (*OptionQuoteRequest, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodDelete,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiQuoteRequest, requestID)),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *OptionQuoteRequest
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+// GetOptionQuoteRequestQuotes returns the quotes made on one of our quote requests.
+func (o *Options) GetOptionQuoteRequestQuotes(requestID int64) ([]OptionQuote, error) {
+	return o.GetOptionQuoteRequestQuotesWithContext(context.Background(), requestID)
+}
+
+func (o *Options) GetOptionQuoteRequestQuotesWithContext(ctx context.Context, requestID int64) ([]OptionQuote, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiQuoteRequestQuotes, requestID)),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []OptionQuote
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+// CreateOptionQuote quotes price on the quote request of another user.
+func (o *Options) CreateOptionQuote(requestID int64, price decimal.Decimal) (*OptionQuote, error) {
+	return o.CreateOptionQuoteWithContext(context.Background(), requestID, price)
+}
+
+func (o *Options) CreateOptionQuoteWithContext(ctx context.Context, requestID int64, price decimal.Decimal) (*OptionQuote, error) {
+	body, err := json.Marshal(struct {
+		Price decimal.Decimal `json:"price"`
+	}{Price: price})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiQuoteRequestQuotes, requestID)),
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *OptionQuote
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) GetMyOptionQuotes() ([]OptionQuote, error) {
+	return o.GetMyOptionQuotesWithContext(context.Background())
+}
+
+func (o *Options) GetMyOptionQuotesWithContext(ctx context.Context) ([]OptionQuote, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiMyOptionQuotes),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []OptionQuote
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) CancelOptionQuote(quoteID int64) (*OptionQuote, error) {
+	return o.CancelOptionQuoteWithContext(context.Background(), quoteID)
+}
+
+func (o *Options) CancelOptionQuoteWithContext(ctx context.Context, quoteID int64) (*OptionQuote, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodDelete,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiOptionQuote, quoteID)),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *OptionQuote
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) AcceptOptionQuote(quoteID int64) (*OptionQuote, error) {
+	return o.AcceptOptionQuoteWithContext(context.Background(), quoteID)
+}
+
+func (o *Options) AcceptOptionQuoteWithContext(ctx context.Context, quoteID int64) (*OptionQuote, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiAcceptOptionQuote, quoteID)),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *OptionQuote
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) GetOptionsAccountInfo() (*OptionsAccountInfo, error) {
+	return o.GetOptionsAccountInfoWithContext(context.Background())
+}
+
+func (o *Options) GetOptionsAccountInfoWithContext(ctx context.Context) (*OptionsAccountInfo, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiOptionsAccountInfo),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *OptionsAccountInfo
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) GetOptionPositions() ([]OptionPosition, error) {
+	return o.GetOptionPositionsWithContext(context.Background())
+}
+
+func (o *Options) GetOptionPositionsWithContext(ctx context.Context) ([]OptionPosition, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiOptionPositions),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []OptionPosition
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (o *Options) GetOptionFills(params *GetOptionsHistoryParams) ([]OptionFill, error) {
+	return o.GetOptionFillsWithContext(context.Background(), params)
+}
+
+func (o *Options) GetOptionFillsWithContext(ctx context.Context, params *GetOptionsHistoryParams) ([]OptionFill, error) {
+	queryParams, err := PrepareQueryParams(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := o.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiOptionFills),
+		Params: queryParams,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []OptionFill
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+// GetOptionTrades returns the public trades of all options.
+func (o *Options) GetOptionTrades(params *GetOptionsHistoryParams) ([]OptionTrade, error) {
+	return o.GetOptionTradesWithContext(context.Background(), params)
+}
+
+func (o *Options) GetOptionTradesWithContext(ctx context.Context, params *GetOptionsHistoryParams) ([]OptionTrade, error) {
+	queryParams, err := PrepareQueryParams(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := o.client.prepareRequest(ctx, Request{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiOptionTrades),
+		Params: queryParams,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []OptionTrade
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+// GetOptionsVolume returns the options volume of the last 24 hours.
+func (o *Options) GetOptionsVolume() (*OptionsVolume, error) {
+	return o.GetOptionsVolumeWithContext(context.Background())
+}
+
+func (o *Options) GetOptionsVolumeWithContext(ctx context.Context) (*OptionsVolume, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiOptionsVolume),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := o.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *OptionsVolume
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,187 @@
+package goftx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type RoundingMode int
+
+const (
+	RoundNearest RoundingMode = iota
+	RoundDown
+	RoundUp
+	// RoundPassive rounds prices away from the spread: buys down and sells up.
+	// For sizes it rounds down.
+	RoundPassive
+)
+
+// OrderValidationError is returned before a request is sent when an order cannot
+// be expressed in the increments of its market.
+type OrderValidationError struct {
+	Market string
+	Field  string
+	Value  decimal.Decimal
+	Reason string
+}
+
+func (e *OrderValidationError) Error() string {
+	return fmt.Sprintf("invalid order for %s: %s %s %s", e.Market, e.Field, e.Value, e.Reason)
+}
+
+// OrderIncrements are the price and size steps accepted by a market.
+// MinProvideSize is the minimum size of orders that can rest on the book.
+type OrderIncrements struct {
+	PriceIncrement decimal.Decimal
+	SizeIncrement  decimal.Decimal
+	MinProvideSize decimal.Decimal
+}
+
+func MarketIncrements(market *Market) OrderIncrements {
+	return OrderIncrements{
+		PriceIncrement: market.PriceIncrement,
+		SizeIncrement:  market.SizeIncrement,
+		MinProvideSize: market.MinProvideSize,
+	}
+}
+
+// FutureIncrements uses SizeIncrement as the minimum resting size, Future does not report MinProvideSize.
+func FutureIncrements(future *Future) OrderIncrements {
+	return OrderIncrements{
+		PriceIncrement: future.PriceIncrement,
+		SizeIncrement:  future.SizeIncrement,
+		MinProvideSize: future.SizeIncrement,
+	}
+}
+
+// RoundToIncrement rounds value to a multiple of increment, a non positive increment leaves value unchanged.
+// RoundPassive rounds down, use RoundPrice to take the side into account.
+func RoundToIncrement(value, increment decimal.Decimal, mode RoundingMode) decimal.Decimal {
+	if !increment.IsPositive() {
+		return value
+	}
+
+	steps := value.Div(increment)
+	switch mode {
+	case RoundUp:
+		steps = steps.Ceil()
+	case RoundDown, RoundPassive:
+		steps = steps.Floor()
+	default:
+		steps = steps.Round(0)
+	}
+
+	return steps.Mul(increment)
+}
+
+func (i OrderIncrements) RoundPrice(price decimal.Decimal, side string, mode RoundingMode) decimal.Decimal {
+	if mode == RoundPassive && side == SideSell {
+		mode = RoundUp
+	}
+	return RoundToIncrement(price, i.PriceIncrement, mode)
+}
+
+func (i OrderIncrements) RoundSize(size decimal.Decimal, mode RoundingMode) decimal.Decimal {
+	return RoundToIncrement(size, i.SizeIncrement, mode)
+}
+
+// NormalizeOrder rounds the price and size of payload in place and validates the result.
+// Market orders keep their price, limit orders that may rest have to reach MinProvideSize.
+func (i OrderIncrements) NormalizeOrder(payload *PlaceOrderPayload, priceMode, sizeMode RoundingMode) error {
+	if payload.Type != OrderTypeMarketOrder {
+		payload.Price = i.RoundPrice(payload.Price, payload.Side, priceMode)
+		if !payload.Price.IsPositive() {
+			return &OrderValidationError{Market: payload.Market, Field: "price", Value: payload.Price, Reason: "must be positive"}
+		}
+	}
+
+	payload.Size = i.RoundSize(payload.Size, sizeMode)
+	return i.validateSize(payload.Market, payload.Size, payload.Type == OrderTypeLimitOrder && !payload.IOC)
+}
+
+// NormalizeModification rounds the price and size of a modification of order in place and validates the result.
+func (i OrderIncrements) NormalizeModification(order *Order, payload *ModifyOrderPayload, priceMode, sizeMode RoundingMode) error {
+	if payload.Price != nil {
+		price := i.RoundPrice(*payload.Price, order.Side, priceMode)
+		if !price.IsPositive() {
+			return &OrderValidationError{Market: order.Market, Field: "price", Value: price, Reason: "must be positive"}
+		}
+		payload.Price = &price
+	}
+
+	if payload.Size != nil {
+		size := i.RoundSize(*payload.Size, sizeMode)
+		err := i.validateSize(order.Market, size, !order.Ioc)
+		if err != nil {
+			return err
+		}
+		payload.Size = &size
+	}
+
+	return nil
+}
+
+func (i OrderIncrements) validateSize(market string, size decimal.Decimal, resting bool) error {
+	if !size.IsPositive() {
+		return &OrderValidationError{Market: market, Field: "size", Value: size, Reason: "must be positive"}
+	}
+	if size.LessThan(i.SizeIncrement) {
+		return &OrderValidationError{Market: market, Field: "size", Value: size, Reason: fmt.Sprintf("is below the size increment %s", i.SizeIncrement)}
+	}
+	if resting && size.LessThan(i.MinProvideSize) {
+		return &OrderValidationError{Market: market, Field: "size", Value: size, Reason: fmt.Sprintf("is below the minimum provide size %s", i.MinProvideSize)}
+	}
+	return nil
+}
+
+// WithOrderNormalization makes PlaceOrder, ModifyOrder and ModifyOrderByClientID round prices and sizes
+// to the increments of the market and reject invalid orders before they are sent.
+// Increments are looked up in the client's MarketRegistry.
+func WithOrderNormalization(priceMode, sizeMode RoundingMode) Option {
+	return func(c *Client) {
+		c.normalizer = &orderNormalizer{
+			priceMode: priceMode,
+			sizeMode:  sizeMode,
+		}
+	}
+}
+
+type orderNormalizer struct {
+	priceMode RoundingMode
+	sizeMode  RoundingMode
+}
+
+// normalizeOrder returns a normalized copy of payload, the caller's payload is left untouched.
+func (c *Client) normalizeOrder(ctx context.Context, payload *PlaceOrderPayload) (*PlaceOrderPayload, error) {
+	increments, err := c.marketRegistry.Increments(ctx, payload.Market)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	normalized := *payload
+	err = increments.NormalizeOrder(&normalized, c.normalizer.priceMode, c.normalizer.sizeMode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &normalized, nil
+}
+
+// normalizeModification returns a normalized copy of payload for order, the caller's payload is left untouched.
+func (c *Client) normalizeModification(ctx context.Context, order *Order, payload *ModifyOrderPayload) (*ModifyOrderPayload, error) {
+	increments, err := c.marketRegistry.Increments(ctx, order.Market)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	normalized := *payload
+	err = increments.NormalizeModification(order, &normalized, c.normalizer.priceMode, c.normalizer.sizeMode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &normalized, nil
+}
@@ -0,0 +1,273 @@
+package goftx
+
+import (
+	"context"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const checksumDepth = 100
+
+var (
+	ErrChecksumMismatch = errors.New("orderbook checksum mismatch")
+	ErrMissingSnapshot  = errors.New("orderbook update received before partial")
+)
+
+// LocalOrderBook is a client side copy of a market orderbook built from the orderbook channel.
+// Bids are kept sorted from the best (highest) price down and asks from the best (lowest) price up.
+type LocalOrderBook struct {
+	mu       sync.RWMutex
+	market   string
+	bids     [][]decimal.Decimal
+	asks     [][]decimal.Decimal
+	checksum uint32
+	time     time.Time
+	synced   bool
+}
+
+func NewLocalOrderBook(market string) *LocalOrderBook {
+	return &LocalOrderBook{market: market}
+}
+
+func (b *LocalOrderBook) Market() string {
+	return b.market
+}
+
+// Apply replaces the book on a partial message and merges the levels of an update message,
+// then verifies the result against the checksum sent by the exchange.
+// After ErrChecksumMismatch or ErrMissingSnapshot the book must be rebuilt from a new partial.
+func (b *LocalOrderBook) Apply(response *OrderBookResponse) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch response.Type {
+	case ResponseTypePartial:
+		b.bids = b.bids[:0]
+		b.asks = b.asks[:0]
+		b.synced = true
+	case ResponseTypeUpdate:
+		if !b.synced {
+			return errors.WithStack(ErrMissingSnapshot)
+		}
+	default:
+		return errors.Errorf("unexpected orderbook message type: %s", response.Type)
+	}
+
+	for _, level := range response.Bids {
+		b.bids = applyLevel(b.bids, level, true)
+	}
+	for _, level := range response.Asks {
+		b.asks = applyLevel(b.asks, level, false)
+	}
+	b.time = response.Time.Time
+
+	b.checksum = b.calculateChecksum()
+	if b.checksum != uint32(response.Checksum) {
+		b.synced = false
+		return errors.Wrapf(ErrChecksumMismatch, "market %s: expected %d, calculated %d", b.market, uint32(response.Checksum), b.checksum)
+	}
+
+	return nil
+}
+
+func (b *LocalOrderBook) Checksum() uint32 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.checksum
+}
+
+// Bids returns up to depth best bids, all of them when depth is not positive.
+func (b *LocalOrderBook) Bids(depth int) [][]decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return copyLevels(b.bids, depth)
+}
+
+// Asks returns up to depth best asks, all of them when depth is not positive.
+func (b *LocalOrderBook) Asks(depth int) [][]decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return copyLevels(b.asks, depth)
+}
+
+func (b *LocalOrderBook) BestBid() (price, size decimal.Decimal, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.bids) == 0 {
+		return decimal.Zero, decimal.Zero, false
+	}
+	return b.bids[0][0], b.bids[0][1], true
+}
+
+func (b *LocalOrderBook) BestAsk() (price, size decimal.Decimal, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.asks) == 0 {
+		return decimal.Zero, decimal.Zero, false
+	}
+	return b.asks[0][0], b.asks[0][1], true
+}
+
+// Snapshot returns a copy of the whole book in the same shape as Markets.GetOrderBook.
+func (b *LocalOrderBook) Snapshot() *OrderBook {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return &OrderBook{
+		Bids:     copyLevels(b.bids, 0),
+		Asks:     copyLevels(b.asks, 0),
+		Checksum: int64(b.checksum),
+		Time:     FTXTime{Time: b.time},
+	}
+}
+
+// calculateChecksum implements the algorithm described on OrderBook.
+func (b *LocalOrderBook) calculateChecksum() uint32 {
+	var sb strings.Builder
+	for i := 0; i < checksumDepth; i++ {
+		if i < len(b.bids) {
+			writeChecksumLevel(&sb, b.bids[i])
+		}
+		if i < len(b.asks) {
+			writeChecksumLevel(&sb, b.asks[i])
+		}
+	}
+
+	return crc32.ChecksumIEEE([]byte(sb.String()))
+}
+
+func writeChecksumLevel(sb *strings.Builder, level []decimal.Decimal) {
+	if sb.Len() > 0 {
+		sb.WriteByte(':')
+	}
+	sb.WriteString(formatChecksumFloat(level[0]))
+	sb.WriteByte(':')
+	sb.WriteString(formatChecksumFloat(level[1]))
+}
+
+// formatChecksumFloat formats a value the way the exchange does (Python float repr):
+// integral values keep a trailing ".0" and very small or large values use exponent notation.
+func formatChecksumFloat(value decimal.Decimal) string {
+	f, _ := value.Float64()
+	if f == 0 {
+		return "0.0"
+	}
+
+	// Go and Python agree on the shortest exponent form, e.g. 1e-05 and 1.5e+16.
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	exp, _ := strconv.Atoi(s[strings.IndexByte(s, 'e')+1:])
+	if exp < -4 || exp >= 16 {
+		return s
+	}
+
+	s = strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+func applyLevel(levels [][]decimal.Decimal, level []decimal.Decimal, descending bool) [][]decimal.Decimal {
+	if len(level) < 2 {
+		return levels
+	}
+	price, size := level[0], level[1]
+
+	i := sort.Search(len(levels), func(i int) bool {
+		if descending {
+			return levels[i][0].LessThanOrEqual(price)
+		}
+		return levels[i][0].GreaterThanOrEqual(price)
+	})
+	found := i < len(levels) && levels[i][0].Equal(price)
+
+	switch {
+	case size.IsZero() && found:
+		return append(levels[:i], levels[i+1:]...)
+	case size.IsZero():
+		return levels
+	case found:
+		levels[i] = []decimal.Decimal{price, size}
+		return levels
+	}
+
+	levels = append(levels, nil)
+	copy(levels[i+1:], levels[i:])
+	levels[i] = []decimal.Decimal{price, size}
+	return levels
+}
+
+func copyLevels(levels [][]decimal.Decimal, depth int) [][]decimal.Decimal {
+	if depth <= 0 || depth > len(levels) {
+		depth = len(levels)
+	}
+
+	result := make([][]decimal.Decimal, depth)
+	for i := 0; i < depth; i++ {
+		result[i] = []decimal.Decimal{levels[i][0], levels[i][1]}
+	}
+	return result
+}
+
+// SubscribeToLocalOrderBook maintains a LocalOrderBook for market from the orderbook channel.
+// The book is sent on the returned channel after every verified partial or update.
+// When verification fails the error is passed to the error handler and the market is
+// resubscribed to receive a fresh partial.
+func (s *Stream) SubscribeToLocalOrderBook(ctx context.Context, market string) (<-chan *LocalOrderBook, error) {
+	book := NewLocalOrderBook(market)
+
+	subCtx, cancel := context.WithCancel(ctx)
+	responsesC, err := s.SubscribeToOrderBooks(subCtx, market)
+	if err != nil {
+		cancel()
+		return nil, errors.WithStack(err)
+	}
+
+	resultC := make(chan *LocalOrderBook)
+	go func() {
+		defer close(resultC)
+		for {
+			resync := s.maintainOrderBook(ctx, book, responsesC, resultC)
+			cancel()
+			if !resync {
+				return
+			}
+
+			subCtx, cancel = context.WithCancel(ctx)
+			responsesC, err = s.SubscribeToOrderBooks(subCtx, market)
+			if err != nil {
+				cancel()
+				s.handleError(err)
+				return
+			}
+		}
+	}()
+
+	return resultC, nil
+}
+
+// maintainOrderBook applies responses to book until the subscription ends
+// and reports whether it stopped because the book needs to be resynced.
+func (s *Stream) maintainOrderBook(ctx context.Context, book *LocalOrderBook, responsesC <-chan *OrderBookResponse, resultC chan<- *LocalOrderBook) bool {
+	for response := range responsesC {
+		err := book.Apply(response)
+		if err != nil {
+			s.handleError(err)
+			return ctx.Err() == nil
+		}
+
+		select {
+		case resultC <- book:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package goftx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFormatChecksumFloat(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"0", "0.0"},
+		{"30000", "30000.0"},
+		{"30000.50", "30000.5"},
+		{"0.10", "0.1"},
+		{"0.0001", "0.0001"},
+		{"0.00005", "5e-05"},
+		{"0.00002345", "2.345e-05"},
+		{"1234567890123456", "1234567890123456.0"},
+		{"12000000000000000", "1.2e+16"},
+		{"700000000000000000000", "7e+20"},
+	}
+
+	for _, test := range tests {
+		if got := formatChecksumFloat(decimal.RequireFromString(test.value)); got != test.want {
+			t.Errorf("formatChecksumFloat(%s) = %s, want %s", test.value, got, test.want)
+		}
+	}
+}
+
+// The expected checksums are the crc32 of the Python float reprs, the reference implementation of the exchange.
+func TestLocalOrderBookChecksum(t *testing.T) {
+	tests := []struct {
+		market          string
+		partial         string
+		partialChecksum int64
+		update          string
+		updateChecksum  int64
+	}{
+		{
+			market:          "BTC-PERP",
+			partial:         `{"bids":[[30000.0,1.5],[29999.5,0.10],[29998,0.0001]],"asks":[[30000.5,2.0],[30001,0.00005],[30002.5,12]],"checksum":3207446961,"time":1609459200.123}`,
+			partialChecksum: 3207446961,
+			update:          `{"bids":[[29999.5,0],[29999.75,3.25]],"asks":[[30000.5,1.9999],[30001,0]],"checksum":1220311776,"time":1609459200.456}`,
+			updateChecksum:  1220311776,
+		},
+		{
+			market:          "SHIB/USD",
+			partial:         `{"bids":[[2.345e-05,1.2e+16],[2.34e-05,50000000.0]],"asks":[[2.35e-05,1.5e+16],[2.36e-05,100000.0]],"checksum":2177552725,"time":1609459200.123}`,
+			partialChecksum: 2177552725,
+			update:          `{"bids":[[2.344e-05,7e+20]],"asks":[[2.35e-05,0.0],[2.355e-05,3]],"checksum":1532060574,"time":1609459200.456}`,
+			updateChecksum:  1532060574,
+		},
+	}
+
+	for _, test := range tests {
+		book := NewLocalOrderBook(test.market)
+
+		for _, message := range []struct {
+			responseType string
+			data         string
+			checksum     int64
+		}{
+			{ResponseTypePartial, test.partial, test.partialChecksum},
+			{ResponseTypeUpdate, test.update, test.updateChecksum},
+		} {
+			var orderBook OrderBook
+			if err := json.Unmarshal([]byte(message.data), &orderBook); err != nil {
+				t.Fatal(err)
+			}
+
+			err := book.Apply(&OrderBookResponse{
+				BaseResponse: BaseResponse{Type: message.responseType, Market: test.market},
+				OrderBook:    orderBook,
+			})
+			if err != nil {
+				t.Fatalf("%s %s: %v", test.market, message.responseType, err)
+			}
+			if got := int64(book.Checksum()); got != message.checksum {
+				t.Errorf("%s %s checksum = %d, want %d", test.market, message.responseType, got, message.checksum)
+			}
+		}
+	}
+}
+
+func TestLocalOrderBookChecksumMismatch(t *testing.T) {
+	book := NewLocalOrderBook("BTC-PERP")
+
+	var orderBook OrderBook
+	err := json.Unmarshal([]byte(`{"bids":[[30000.0,1.5]],"asks":[[30000.5,2.0]],"checksum":1,"time":1609459200.123}`), &orderBook)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = book.Apply(&OrderBookResponse{BaseResponse: BaseResponse{Type: ResponseTypePartial}, OrderBook: orderBook})
+	if err == nil {
+		t.Fatal("wrong checksum was accepted")
+	}
+
+	err = book.Apply(&OrderBookResponse{BaseResponse: BaseResponse{Type: ResponseTypeUpdate}, OrderBook: orderBook})
+	if err == nil {
+		t.Fatal("update after a mismatch was accepted")
+	}
+}
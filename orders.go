@@ -1,6 +1,7 @@
 package goftx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -162,10 +163,14 @@ type Orders struct {
 }
 
 func (o *Orders) GetOpenOrders(market string) ([]Order, error) {
+	return o.GetOpenOrdersWithContext(context.Background(), market)
+}
+
+func (o *Orders) GetOpenOrdersWithContext(ctx context.Context, market string) ([]Order, error) {
 	requestParams := Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiOrders),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiOrders),
 	}
 	if market != "" {
 		requestParams.Params = map[string]string{
@@ -173,7 +178,7 @@ func (o *Orders) GetOpenOrders(market string) ([]Order, error) {
 		}
 	}
 
-	request, err := o.client.prepareRequest(requestParams)
+	request, err := o.client.prepareRequest(ctx, requestParams)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -193,15 +198,19 @@ func (o *Orders) GetOpenOrders(market string) ([]Order, error) {
 }
 
 func (o *Orders) GetOrdersHistory(params *GetOrdersHistoryParams) ([]Order, error) {
+	return o.GetOrdersHistoryWithContext(context.Background(), params)
+}
+
+func (o *Orders) GetOrdersHistoryWithContext(ctx context.Context, params *GetOrdersHistoryParams) ([]Order, error) {
 	queryParams, err := PrepareQueryParams(params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiGetOrdersHistory),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiGetOrdersHistory),
 		Params: queryParams,
 	})
 	if err != nil {
@@ -223,15 +232,19 @@ func (o *Orders) GetOrdersHistory(params *GetOrdersHistoryParams) ([]Order, erro
 }
 
 func (o *Orders) GetOpenTriggerOrders(params *GetOpenTriggerOrdersParams) ([]TriggerOrder, error) {
+	return o.GetOpenTriggerOrdersWithContext(context.Background(), params)
+}
+
+func (o *Orders) GetOpenTriggerOrdersWithContext(ctx context.Context, params *GetOpenTriggerOrdersParams) ([]TriggerOrder, error) {
 	queryParams, err := PrepareQueryParams(params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiTriggerOrders),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiTriggerOrders),
 		Params: queryParams,
 	})
 	if err != nil {
@@ -253,10 +266,14 @@ func (o *Orders) GetOpenTriggerOrders(params *GetOpenTriggerOrdersParams) ([]Tri
 }
 
 func (o *Orders) GetOrderTriggers(orderID int64) ([]Trigger, error) {
-	request, err := o.client.prepareRequest(Request{
+	return o.GetOrderTriggersWithContext(context.Background(), orderID)
+}
+
+func (o *Orders) GetOrderTriggersWithContext(ctx context.Context, orderID int64) ([]Trigger, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, fmt.Sprintf(apiGetOrderTriggers, orderID)),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiGetOrderTriggers, orderID)),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -277,15 +294,19 @@ func (o *Orders) GetOrderTriggers(orderID int64) ([]Trigger, error) {
 }
 
 func (o *Orders) GetTriggerOrdersHistory(params *GetTriggerOrdersHistoryParams) ([]TriggerOrder, error) {
+	return o.GetTriggerOrdersHistoryWithContext(context.Background(), params)
+}
+
+func (o *Orders) GetTriggerOrdersHistoryWithContext(ctx context.Context, params *GetTriggerOrdersHistoryParams) ([]TriggerOrder, error) {
 	queryParams, err := PrepareQueryParams(params)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiGetTriggerOrdersHistory),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiGetTriggerOrdersHistory),
 		Params: queryParams,
 	})
 	if err != nil {
@@ -307,15 +328,27 @@ func (o *Orders) GetTriggerOrdersHistory(params *GetTriggerOrdersHistoryParams)
 }
 
 func (o *Orders) PlaceOrder(payload *PlaceOrderPayload) (*Order, error) {
+	return o.PlaceOrderWithContext(context.Background(), payload)
+}
+
+func (o *Orders) PlaceOrderWithContext(ctx context.Context, payload *PlaceOrderPayload) (*Order, error) {
+	if o.client.normalizer != nil {
+		var err error
+		payload, err = o.client.normalizeOrder(ctx, payload)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiOrders),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiOrders),
 		Body:   body,
 	})
 	if err != nil {
@@ -337,6 +370,10 @@ func (o *Orders) PlaceOrder(payload *PlaceOrderPayload) (*Order, error) {
 }
 
 func (o *Orders) PlaceTriggerOrder(payload *PlaceTriggerOrderPayload) (*TriggerOrder, error) {
+	return o.PlaceTriggerOrderWithContext(context.Background(), payload)
+}
+
+func (o *Orders) PlaceTriggerOrderWithContext(ctx context.Context, payload *PlaceTriggerOrderPayload) (*TriggerOrder, error) {
 	err := payload.Validate()
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -347,10 +384,10 @@ func (o *Orders) PlaceTriggerOrder(payload *PlaceTriggerOrderPayload) (*TriggerO
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiTriggerOrders),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiTriggerOrders),
 		Body:   body,
 	})
 	if err != nil {
@@ -372,15 +409,31 @@ func (o *Orders) PlaceTriggerOrder(payload *PlaceTriggerOrderPayload) (*TriggerO
 }
 
 func (o *Orders) ModifyOrder(payload *ModifyOrderPayload, orderID int64) (*Order, error) {
+	return o.ModifyOrderWithContext(context.Background(), payload, orderID)
+}
+
+func (o *Orders) ModifyOrderWithContext(ctx context.Context, payload *ModifyOrderPayload, orderID int64) (*Order, error) {
+	if o.client.normalizer != nil {
+		order, err := o.GetOrderWithContext(ctx, orderID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		payload, err = o.client.normalizeModification(ctx, order, payload)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, fmt.Sprintf(apiModifyOrder, orderID)),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiModifyOrder, orderID)),
 		Body:   body,
 	})
 	if err != nil {
@@ -402,15 +455,31 @@ func (o *Orders) ModifyOrder(payload *ModifyOrderPayload, orderID int64) (*Order
 }
 
 func (o *Orders) ModifyOrderByClientID(payload *ModifyOrderPayload, clientOrderID int64) (*Order, error) {
+	return o.ModifyOrderByClientIDWithContext(context.Background(), payload, clientOrderID)
+}
+
+func (o *Orders) ModifyOrderByClientIDWithContext(ctx context.Context, payload *ModifyOrderPayload, clientOrderID int64) (*Order, error) {
+	if o.client.normalizer != nil {
+		order, err := o.GetOrderByClientIDWithContext(ctx, clientOrderID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		payload, err = o.client.normalizeModification(ctx, order, payload)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, fmt.Sprintf(apiModifyOrderByClientID, clientOrderID)),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiModifyOrderByClientID, clientOrderID)),
 		Body:   body,
 	})
 	if err != nil {
@@ -432,15 +501,19 @@ func (o *Orders) ModifyOrderByClientID(payload *ModifyOrderPayload, clientOrderI
 }
 
 func (o *Orders) ModifyTriggerOrder(payload *ModifyTriggerOrderPayload, orderID int64) (*TriggerOrder, error) {
+	return o.ModifyTriggerOrderWithContext(context.Background(), payload, orderID)
+}
+
+func (o *Orders) ModifyTriggerOrderWithContext(ctx context.Context, payload *ModifyTriggerOrderPayload, orderID int64) (*TriggerOrder, error) {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, fmt.Sprintf(apiModifyTriggerOrder, orderID)),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, fmt.Sprintf(apiModifyTriggerOrder, orderID)),
 		Body:   body,
 	})
 	if err != nil {
@@ -462,10 +535,14 @@ func (o *Orders) ModifyTriggerOrder(payload *ModifyTriggerOrderPayload, orderID
 }
 
 func (o *Orders) GetOrder(orderID int64) (*Order, error) {
-	request, err := o.client.prepareRequest(Request{
+	return o.GetOrderWithContext(context.Background(), orderID)
+}
+
+func (o *Orders) GetOrderWithContext(ctx context.Context, orderID int64) (*Order, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s/%d", apiUrl, apiOrders, orderID),
+		URL:    fmt.Sprintf("%s%s/%d", o.client.apiUrl, apiOrders, orderID),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -486,10 +563,14 @@ func (o *Orders) GetOrder(orderID int64) (*Order, error) {
 }
 
 func (o *Orders) GetOrderByClientID(clientOrderID int64) (*Order, error) {
-	request, err := o.client.prepareRequest(Request{
+	return o.GetOrderByClientIDWithContext(context.Background(), clientOrderID)
+}
+
+func (o *Orders) GetOrderByClientIDWithContext(ctx context.Context, clientOrderID int64) (*Order, error) {
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s/by_client_id/%d", apiUrl, apiOrders, clientOrderID),
+		URL:    fmt.Sprintf("%s%s/by_client_id/%d", o.client.apiUrl, apiOrders, clientOrderID),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -510,10 +591,14 @@ func (o *Orders) GetOrderByClientID(clientOrderID int64) (*Order, error) {
 }
 
 func (o *Orders) CancelOrder(orderID int64) error {
-	request, err := o.client.prepareRequest(Request{
+	return o.CancelOrderWithContext(context.Background(), orderID)
+}
+
+func (o *Orders) CancelOrderWithContext(ctx context.Context, orderID int64) error {
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodDelete,
-		URL:    fmt.Sprintf("%s%s/%d", apiUrl, apiOrders, orderID),
+		URL:    fmt.Sprintf("%s%s/%d", o.client.apiUrl, apiOrders, orderID),
 	})
 	if err != nil {
 		return errors.WithStack(err)
@@ -528,10 +613,14 @@ func (o *Orders) CancelOrder(orderID int64) error {
 }
 
 func (o *Orders) CancelOrderByClientID(clientOrderID int64) error {
-	request, err := o.client.prepareRequest(Request{
+	return o.CancelOrderByClientIDWithContext(context.Background(), clientOrderID)
+}
+
+func (o *Orders) CancelOrderByClientIDWithContext(ctx context.Context, clientOrderID int64) error {
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodDelete,
-		URL:    fmt.Sprintf("%s%s/by_client_id/%d", apiUrl, apiOrders, clientOrderID),
+		URL:    fmt.Sprintf("%s%s/by_client_id/%d", o.client.apiUrl, apiOrders, clientOrderID),
 	})
 	if err != nil {
 		return errors.WithStack(err)
@@ -546,10 +635,14 @@ func (o *Orders) CancelOrderByClientID(clientOrderID int64) error {
 }
 
 func (o *Orders) CancelOpenTriggerOrder(triggerOrderID int64) error {
-	request, err := o.client.prepareRequest(Request{
+	return o.CancelOpenTriggerOrderWithContext(context.Background(), triggerOrderID)
+}
+
+func (o *Orders) CancelOpenTriggerOrderWithContext(ctx context.Context, triggerOrderID int64) error {
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodDelete,
-		URL:    fmt.Sprintf("%s%s/%d", apiUrl, apiTriggerOrders, triggerOrderID),
+		URL:    fmt.Sprintf("%s%s/%d", o.client.apiUrl, apiTriggerOrders, triggerOrderID),
 	})
 	if err != nil {
 		return errors.WithStack(err)
@@ -564,15 +657,19 @@ func (o *Orders) CancelOpenTriggerOrder(triggerOrderID int64) error {
 }
 
 func (o *Orders) CancelAllOrders(payload *CancelAllOrdersPayload) error {
+	return o.CancelAllOrdersWithContext(context.Background(), payload)
+}
+
+func (o *Orders) CancelAllOrdersWithContext(ctx context.Context, payload *CancelAllOrdersPayload) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	request, err := o.client.prepareRequest(Request{
+	request, err := o.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodDelete,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiOrders),
+		URL:    fmt.Sprintf("%s%s", o.client.apiUrl, apiOrders),
 		Body:   body,
 	})
 	if err != nil {
@@ -0,0 +1,456 @@
+package goftx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// pageItem is a history record reduced to what the pager needs to walk back in time.
+type pageItem struct {
+	key   string
+	time  time.Time
+	value interface{}
+}
+
+// Largest pages served by the history endpoints, used to read a second that fills a whole page.
+const (
+	maxTradesLimit        = 5000
+	maxOrdersHistoryLimit = 1000
+	maxFillsLimit         = 5000
+)
+
+// timePager walks a history endpoint backwards in time. Each page is requested with end_time
+// set to the oldest record of the previous page, records repeated at the page boundary are
+// skipped and the walk stops at startTime or on the first empty page.
+//
+// A page whose records all share one second cannot move end_time back, that second is read again
+// with maxLimit and the walk fails when it holds maxLimit records or more. Endpoints without a limit
+// parameter (maxLimit 0) are assumed to hold fewer records per second than a page.
+type timePager struct {
+	ctx       context.Context
+	fetch     func(ctx context.Context, startTime, endTime *int64, limit *int) ([]pageItem, error)
+	maxLimit  int
+	startTime *int64
+	endTime   int64
+	lastKeys  map[string]struct{}
+	buffer    []pageItem
+	current   pageItem
+	done      bool
+	err       error
+}
+
+func newTimePager(ctx context.Context, startTime, endTime *int64, maxLimit int, fetch func(ctx context.Context, startTime, endTime *int64, limit *int) ([]pageItem, error)) *timePager {
+	end := time.Now().Unix()
+	if endTime != nil {
+		end = *endTime
+	}
+
+	return &timePager{
+		ctx:       ctx,
+		fetch:     fetch,
+		maxLimit:  maxLimit,
+		startTime: startTime,
+		endTime:   end,
+	}
+}
+
+func (p *timePager) next() bool {
+	for len(p.buffer) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		p.loadPage()
+	}
+
+	p.current, p.buffer = p.buffer[0], p.buffer[1:]
+	return true
+}
+
+func (p *timePager) loadPage() {
+	if p.startTime != nil && p.endTime < *p.startTime {
+		p.done = true
+		return
+	}
+
+	endTime := p.endTime
+	items, err := p.fetch(p.ctx, p.startTime, &endTime, nil)
+	if err != nil {
+		p.err = errors.WithStack(err)
+		return
+	}
+	if len(items) == 0 {
+		p.done = true
+		return
+	}
+
+	keys := make(map[string]struct{}, len(items))
+	oldest := items[0].time
+	for _, item := range items {
+		keys[item.key] = struct{}{}
+		if item.time.Before(oldest) {
+			oldest = item.time
+		}
+
+		if _, ok := p.lastKeys[item.key]; ok {
+			continue
+		}
+		if p.startTime != nil && item.time.Unix() < *p.startTime {
+			continue
+		}
+		p.buffer = append(p.buffer, item)
+	}
+
+	if oldest.Unix() >= p.endTime {
+		for key := range p.lastKeys {
+			keys[key] = struct{}{}
+		}
+		p.loadSecond(keys)
+		return
+	}
+
+	p.lastKeys = keys
+	p.endTime = oldest.Unix()
+}
+
+// loadSecond reads the records of the second at endTime not in seen and steps over that second.
+func (p *timePager) loadSecond(seen map[string]struct{}) {
+	second := p.endTime
+	var limit *int
+	if p.maxLimit > 0 {
+		limit = &p.maxLimit
+	}
+
+	items, err := p.fetch(p.ctx, &second, &second, limit)
+	if err != nil {
+		p.err = errors.WithStack(err)
+		return
+	}
+	if p.maxLimit > 0 && len(items) >= p.maxLimit {
+		p.err = errors.Errorf("%d or more records at %s cannot be paged by time", p.maxLimit, time.Unix(second, 0).UTC())
+		return
+	}
+
+	for _, item := range items {
+		if _, ok := seen[item.key]; ok {
+			continue
+		}
+		seen[item.key] = struct{}{}
+		p.buffer = append(p.buffer, item)
+	}
+	p.lastKeys = nil
+	p.endTime = second - 1
+}
+
+type OrdersHistoryIterator struct {
+	pager *timePager
+}
+
+// IterateOrdersHistory returns an iterator over the order history from params.EndTime (or now)
+// back to params.StartTime (or the first order).
+func (o *Orders) IterateOrdersHistory(ctx context.Context, params *GetOrdersHistoryParams) *OrdersHistoryIterator {
+	if params == nil {
+		params = &GetOrdersHistoryParams{}
+	}
+	pageParams := *params
+	return &OrdersHistoryIterator{
+		pager: newTimePager(ctx, intToInt64(params.StartTime), intToInt64(params.EndTime), maxOrdersHistoryLimit, func(ctx context.Context, startTime, endTime *int64, limit *int) ([]pageItem, error) {
+			pageParams.StartTime, pageParams.EndTime = int64ToInt(startTime), int64ToInt(endTime)
+			pageParams.Limit = pageLimit(limit, params.Limit)
+			orders, err := o.GetOrdersHistoryWithContext(ctx, &pageParams)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			items := make([]pageItem, 0, len(orders))
+			for _, order := range orders {
+				items = append(items, pageItem{key: fmt.Sprint(order.ID), time: order.CreatedAt, value: order})
+			}
+			return items, nil
+		}),
+	}
+}
+
+func (it *OrdersHistoryIterator) Next() bool {
+	return it.pager.next()
+}
+
+func (it *OrdersHistoryIterator) Order() Order {
+	return it.pager.current.value.(Order)
+}
+
+func (it *OrdersHistoryIterator) Err() error {
+	return it.pager.err
+}
+
+type TriggerOrdersHistoryIterator struct {
+	pager *timePager
+}
+
+// IterateTriggerOrdersHistory returns an iterator over the trigger order history from params.EndTime (or now)
+// back to params.StartTime (or the first trigger order).
+func (o *Orders) IterateTriggerOrdersHistory(ctx context.Context, params *GetTriggerOrdersHistoryParams) *TriggerOrdersHistoryIterator {
+	if params == nil {
+		params = &GetTriggerOrdersHistoryParams{}
+	}
+	pageParams := *params
+	return &TriggerOrdersHistoryIterator{
+		pager: newTimePager(ctx, intToInt64(params.StartTime), intToInt64(params.EndTime), maxOrdersHistoryLimit, func(ctx context.Context, startTime, endTime *int64, limit *int) ([]pageItem, error) {
+			pageParams.StartTime, pageParams.EndTime = int64ToInt(startTime), int64ToInt(endTime)
+			pageParams.Limit = pageLimit(limit, params.Limit)
+			orders, err := o.GetTriggerOrdersHistoryWithContext(ctx, &pageParams)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			items := make([]pageItem, 0, len(orders))
+			for _, order := range orders {
+				items = append(items, pageItem{key: fmt.Sprint(order.ID), time: order.CreatedAt, value: order})
+			}
+			return items, nil
+		}),
+	}
+}
+
+func (it *TriggerOrdersHistoryIterator) Next() bool {
+	return it.pager.next()
+}
+
+func (it *TriggerOrdersHistoryIterator) TriggerOrder() TriggerOrder {
+	return it.pager.current.value.(TriggerOrder)
+}
+
+func (it *TriggerOrdersHistoryIterator) Err() error {
+	return it.pager.err
+}
+
+type FillsIterator struct {
+	pager *timePager
+}
+
+// IterateFills returns an iterator over fills from params.EndTime (or now) back to
+// params.StartTime (or the first fill). params.Order is ignored, fills are always walked newest first.
+func (f *Fills) IterateFills(ctx context.Context, params *GetFillsParams) *FillsIterator {
+	if params == nil {
+		params = &GetFillsParams{}
+	}
+	pageParams := *params
+	pageParams.Order = nil
+	return &FillsIterator{
+		pager: newTimePager(ctx, params.StartTime, params.EndTime, maxFillsLimit, func(ctx context.Context, startTime, endTime *int64, limit *int) ([]pageItem, error) {
+			pageParams.StartTime, pageParams.EndTime = startTime, endTime
+			pageParams.Limit = pageLimit(limit, params.Limit)
+			fills, err := f.GetFillsWithContext(ctx, &pageParams)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			items := make([]pageItem, 0, len(fills))
+			for _, fill := range fills {
+				items = append(items, pageItem{key: fmt.Sprint(fill.ID), time: fill.Time.Time, value: fill})
+			}
+			return items, nil
+		}),
+	}
+}
+
+func (it *FillsIterator) Next() bool {
+	return it.pager.next()
+}
+
+func (it *FillsIterator) Fill() Fill {
+	return it.pager.current.value.(Fill)
+}
+
+func (it *FillsIterator) Err() error {
+	return it.pager.err
+}
+
+type TradesIterator struct {
+	pager *timePager
+}
+
+// IterateTrades returns an iterator over the trades of marketName from params.EndTime (or now)
+// back to params.StartTime (or the first trade).
+func (m *Markets) IterateTrades(ctx context.Context, marketName string, params *GetTradesParams) *TradesIterator {
+	if params == nil {
+		params = &GetTradesParams{}
+	}
+	pageParams := *params
+	return &TradesIterator{
+		pager: newTimePager(ctx, intToInt64(params.StartTime), intToInt64(params.EndTime), maxTradesLimit, func(ctx context.Context, startTime, endTime *int64, limit *int) ([]pageItem, error) {
+			pageParams.StartTime, pageParams.EndTime = int64ToInt(startTime), int64ToInt(endTime)
+			pageParams.Limit = pageLimit(limit, params.Limit)
+			trades, err := m.GetTradesWithContext(ctx, marketName, &pageParams)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			items := make([]pageItem, 0, len(trades))
+			for _, trade := range trades {
+				items = append(items, pageItem{key: fmt.Sprint(trade.ID), time: trade.Time, value: trade})
+			}
+			return items, nil
+		}),
+	}
+}
+
+func (it *TradesIterator) Next() bool {
+	return it.pager.next()
+}
+
+func (it *TradesIterator) Trade() Trade {
+	return it.pager.current.value.(Trade)
+}
+
+func (it *TradesIterator) Err() error {
+	return it.pager.err
+}
+
+type FundingRatesIterator struct {
+	pager *timePager
+}
+
+// IterateFundingRates returns an iterator over funding rates from params.EndTime (or now)
+// back to params.StartTime (or the first funding rate).
+func (f *Futures) IterateFundingRates(ctx context.Context, params *GetFundingRatesParams) *FundingRatesIterator {
+	if params == nil {
+		params = &GetFundingRatesParams{}
+	}
+	pageParams := *params
+	return &FundingRatesIterator{
+		pager: newTimePager(ctx, intToInt64(params.StartTime), intToInt64(params.EndTime), 0, func(ctx context.Context, startTime, endTime *int64, _ *int) ([]pageItem, error) {
+			pageParams.StartTime, pageParams.EndTime = int64ToInt(startTime), int64ToInt(endTime)
+			rates, err := f.GetFundingRatesWithContext(ctx, &pageParams)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			items := make([]pageItem, 0, len(rates))
+			for _, rate := range rates {
+				// Funding rates have no id, a future is funded once per timestamp.
+				key := fmt.Sprintf("%s:%d", rate.Future, rate.Time.Unix())
+				items = append(items, pageItem{key: key, time: rate.Time, value: rate})
+			}
+			return items, nil
+		}),
+	}
+}
+
+func (it *FundingRatesIterator) Next() bool {
+	return it.pager.next()
+}
+
+func (it *FundingRatesIterator) FundingRate() FundingRate {
+	return it.pager.current.value.(FundingRate)
+}
+
+func (it *FundingRatesIterator) Err() error {
+	return it.pager.err
+}
+
+type FundingPaymentsIterator struct {
+	pager *timePager
+}
+
+// IterateFundingPayments returns an iterator over the funding payments of the account from params.EndTime (or now)
+// back to params.StartTime (or the first payment).
+func (f *Futures) IterateFundingPayments(ctx context.Context, params *GetFundingPaymentsParams) *FundingPaymentsIterator {
+	if params == nil {
+		params = &GetFundingPaymentsParams{}
+	}
+	pageParams := *params
+	return &FundingPaymentsIterator{
+		pager: newTimePager(ctx, intToInt64(params.StartTime), intToInt64(params.EndTime), 0, func(ctx context.Context, startTime, endTime *int64, _ *int) ([]pageItem, error) {
+			pageParams.StartTime, pageParams.EndTime = int64ToInt(startTime), int64ToInt(endTime)
+			payments, err := f.GetFundingPaymentsWithContext(ctx, &pageParams)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			items := make([]pageItem, 0, len(payments))
+			for _, payment := range payments {
+				items = append(items, pageItem{key: fmt.Sprint(payment.ID), time: payment.Time, value: payment})
+			}
+			return items, nil
+		}),
+	}
+}
+
+func (it *FundingPaymentsIterator) Next() bool {
+	return it.pager.next()
+}
+
+func (it *FundingPaymentsIterator) FundingPayment() FundingPayment {
+	return it.pager.current.value.(FundingPayment)
+}
+
+func (it *FundingPaymentsIterator) Err() error {
+	return it.pager.err
+}
+
+type StakingRewardsIterator struct {
+	pager *timePager
+}
+
+// IterateStakingRewards returns an iterator over the staking rewards from params.EndTime (or now)
+// back to params.StartTime (or the first reward).
+func (s *Staking) IterateStakingRewards(ctx context.Context, params *GetStakingRewardsParams) *StakingRewardsIterator {
+	if params == nil {
+		params = &GetStakingRewardsParams{}
+	}
+	pageParams := *params
+	return &StakingRewardsIterator{
+		pager: newTimePager(ctx, intToInt64(params.StartTime), intToInt64(params.EndTime), 0, func(ctx context.Context, startTime, endTime *int64, _ *int) ([]pageItem, error) {
+			pageParams.StartTime, pageParams.EndTime = int64ToInt(startTime), int64ToInt(endTime)
+			rewards, err := s.GetStakingRewardsWithContext(ctx, &pageParams)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			items := make([]pageItem, 0, len(rewards))
+			for _, reward := range rewards {
+				items = append(items, pageItem{key: fmt.Sprint(reward.ID), time: reward.Time, value: reward})
+			}
+			return items, nil
+		}),
+	}
+}
+
+func (it *StakingRewardsIterator) Next() bool {
+	return it.pager.next()
+}
+
+func (it *StakingRewardsIterator) StakingReward() StakingReward {
+	return it.pager.current.value.(StakingReward)
+}
+
+func (it *StakingRewardsIterator) Err() error {
+	return it.pager.err
+}
+
+// pageLimit is the limit of a page, the pager's when it reads a single second and the caller's otherwise.
+func pageLimit(limit, paramsLimit *int) *int {
+	if limit != nil {
+		return limit
+	}
+	return paramsLimit
+}
+
+func intToInt64(value *int) *int64 {
+	if value == nil {
+		return nil
+	}
+	result := int64(*value)
+	return &result
+}
+
+func int64ToInt(value *int64) *int {
+	if value == nil {
+		return nil
+	}
+	result := int(*value)
+	return &result
+}
@@ -0,0 +1,121 @@
+package goftx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/wizpacekorea/goftx"
+	"github.com/wizpacekorea/goftx/goftxtest"
+)
+
+func newTradesServer(t *testing.T) *goftxtest.Server {
+	server := goftxtest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.AddMarket(goftx.Market{Name: "BTC/USD", Type: goftx.MarketTypeSpot})
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	server.AddTrades("BTC/USD",
+		goftx.Trade{ID: 1, Price: dec("100"), Size: dec("1"), Time: start},
+		goftx.Trade{ID: 2, Price: dec("101"), Size: dec("1"), Time: start.Add(time.Second)},
+		goftx.Trade{ID: 3, Price: dec("102"), Size: dec("1"), Time: start.Add(time.Second)},
+		goftx.Trade{ID: 4, Price: dec("103"), Size: dec("1"), Time: start.Add(2 * time.Second)},
+		goftx.Trade{ID: 5, Price: dec("104"), Size: dec("1"), Time: start.Add(3 * time.Second)},
+	)
+	return server
+}
+
+func collectTrades(t *testing.T, it *goftx.TradesIterator) []int64 {
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, it.Trade().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, got []int64, want ...int64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("ids = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestIterateTradesNilParams(t *testing.T) {
+	server := newTradesServer(t)
+	client := server.Client()
+
+	ids := collectTrades(t, client.IterateTrades(context.Background(), "BTC/USD", nil))
+	assertIDs(t, ids, 5, 4, 3, 2, 1)
+}
+
+func TestIterateTradesPageBoundaries(t *testing.T) {
+	server := newTradesServer(t)
+	client := server.Client()
+
+	// Pages of two overlap on the oldest second of the previous page, the repeated trades are skipped.
+	pageSize := 2
+	ids := collectTrades(t, client.IterateTrades(context.Background(), "BTC/USD", &goftx.GetTradesParams{Limit: &pageSize}))
+	assertIDs(t, ids, 5, 4, 3, 2, 1)
+
+	startTime := int(time.Date(2021, 1, 1, 0, 0, 1, 0, time.UTC).Unix())
+	ids = collectTrades(t, client.IterateTrades(context.Background(), "BTC/USD", &goftx.GetTradesParams{Limit: &pageSize, StartTime: &startTime}))
+	assertIDs(t, ids, 5, 4, 3, 2)
+
+	// A full page inside one second is read again with a larger page.
+	start := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+	server.AddMarket(goftx.Market{Name: "ETH/USD", Type: goftx.MarketTypeSpot})
+	server.AddTrades("ETH/USD",
+		goftx.Trade{ID: 11, Price: dec("10"), Size: dec("1"), Time: start},
+		goftx.Trade{ID: 12, Price: dec("10"), Size: dec("1"), Time: start.Add(time.Second)},
+		goftx.Trade{ID: 13, Price: dec("10"), Size: dec("1"), Time: start.Add(time.Second)},
+		goftx.Trade{ID: 14, Price: dec("10"), Size: dec("1"), Time: start.Add(time.Second)},
+		goftx.Trade{ID: 15, Price: dec("10"), Size: dec("1"), Time: start.Add(2 * time.Second)},
+	)
+	ids = collectTrades(t, client.IterateTrades(context.Background(), "ETH/USD", &goftx.GetTradesParams{Limit: &pageSize}))
+	assertIDs(t, ids, 15, 14, 13, 12, 11)
+}
+
+func TestIterateTradesSecondTooLarge(t *testing.T) {
+	server := goftxtest.NewServer()
+	defer server.Close()
+	server.AddMarket(goftx.Market{Name: "BTC/USD", Type: goftx.MarketTypeSpot})
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	trades := make([]goftx.Trade, 5000)
+	for i := range trades {
+		trades[i] = goftx.Trade{ID: int64(i + 1), Price: dec("100"), Size: dec("1"), Time: start}
+	}
+	server.AddTrades("BTC/USD", trades...)
+
+	pageSize := 100
+	it := server.Client().IterateTrades(context.Background(), "BTC/USD", &goftx.GetTradesParams{Limit: &pageSize})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if it.Err() == nil {
+		t.Errorf("iterated %d trades of a second holding a full page without an error", count)
+	}
+}
+
+func TestIterateOrdersHistoryNilParams(t *testing.T) {
+	server := goftxtest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	it := client.IterateOrdersHistory(context.Background(), nil)
+	for it.Next() {
+		t.Fatalf("unexpected order %+v", it.Order())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,833 @@
+package goftx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const paperQuoteCurrency = "USD"
+
+// OrderBookSource provides the books the paper trading engine matches orders against.
+type OrderBookSource interface {
+	OrderBook(ctx context.Context, market string) (*OrderBook, error)
+}
+
+type OrderBookSourceFunc func(ctx context.Context, market string) (*OrderBook, error)
+
+func (f OrderBookSourceFunc) OrderBook(ctx context.Context, market string) (*OrderBook, error) {
+	return f(ctx, market)
+}
+
+// LiveOrderBooks reads books with Markets.GetOrderBook. The client may be the paper trading
+// client itself since public endpoints are still sent to the exchange and books are
+// fetched before the paper trader is locked.
+func LiveOrderBooks(client *Client, depth int) OrderBookSource {
+	return OrderBookSourceFunc(func(ctx context.Context, market string) (*OrderBook, error) {
+		return client.GetOrderBookWithContext(ctx, market, &depth)
+	})
+}
+
+// ReplayOrderBooks serves recorded books of each market one after another,
+// the last book of a market is repeated once the recording is exhausted.
+type ReplayOrderBooks struct {
+	mu    sync.Mutex
+	books map[string][]OrderBook
+}
+
+func NewReplayOrderBooks(books map[string][]OrderBook) *ReplayOrderBooks {
+	return &ReplayOrderBooks{books: books}
+}
+
+func (r *ReplayOrderBooks) OrderBook(_ context.Context, market string) (*OrderBook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	books := r.books[market]
+	if len(books) == 0 {
+		return nil, errors.Errorf("no recorded orderbook for %s", market)
+	}
+
+	book := books[0]
+	if len(books) > 1 {
+		r.books[market] = books[1:]
+	}
+	return &book, nil
+}
+
+// PaperTrader is a simulated account with a matching engine, see WithPaperTrading.
+//
+// Market and IOC orders take liquidity from the current book and cancel what is left,
+// post-only orders are cancelled when they would take liquidity and other limit orders
+// take what they can and rest. Resting orders are filled at their price up to the size the
+// opposite side of the book offers at or beyond it, every order request re-checks them against
+// a fresh book, Match does it on demand. Resting spot orders reserve their funds until they are closed.
+// Spot markets settle in their base and quote coins, futures settle fees and realized PnL in USD.
+type PaperTrader struct {
+	mu        sync.Mutex
+	books     OrderBookSource
+	info      AccountInformation
+	balances  map[string]*Balance
+	positions map[string]*Position
+	orders    map[int64]*Order
+	reserved  map[int64]decimal.Decimal
+	fills     []Fill
+	nextID    int64
+	now       func() time.Time
+}
+
+// NewPaperTrader starts a simulated account holding balances and charging the MakerFee
+// and TakerFee of info, as returned by Account.GetAccountInformation.
+func NewPaperTrader(books OrderBookSource, info AccountInformation, balances ...Balance) *PaperTrader {
+	trader := &PaperTrader{
+		books:     books,
+		info:      info,
+		balances:  make(map[string]*Balance),
+		positions: make(map[string]*Position),
+		orders:    make(map[int64]*Order),
+		reserved:  make(map[int64]decimal.Decimal),
+		now:       time.Now,
+	}
+
+	for _, balance := range balances {
+		b := balance
+		trader.balances[balance.Coin] = &b
+	}
+
+	return trader
+}
+
+// WithPaperTrading serves order, fill, balance, position and account requests from trader.
+// Public market data is still requested from the exchange and any other private request fails.
+func WithPaperTrading(trader *PaperTrader) Option {
+	return func(c *Client) {
+		c.paperTrader = trader
+	}
+}
+
+func (p *PaperTrader) Balances() []Balance {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.listBalances()
+}
+
+func (p *PaperTrader) Positions() []Position {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.listPositions()
+}
+
+func (p *PaperTrader) Fills() []Fill {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Fill(nil), p.fills...)
+}
+
+// Match fills resting orders that the current books have crossed.
+func (p *PaperTrader) Match(ctx context.Context) error {
+	books, err := p.orderBooks(ctx, "", apiOrders, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.matchResting(books)
+	return nil
+}
+
+// serve answers req when it is handled by the paper trader.
+// Private requests that are not handled are refused so that nothing reaches the real account.
+func (p *PaperTrader) serve(c *Client, req *http.Request) ([]byte, bool, error) {
+	base, err := url.Parse(c.apiUrl)
+	if err != nil {
+		return nil, true, errors.WithStack(err)
+	}
+	path := strings.TrimPrefix(req.URL.Path, base.Path)
+	private := req.Header.Get(c.header(signHeader)) != ""
+
+	if !handlesPath(req.Method, path) {
+		if private {
+			return nil, true, errors.WithStack(p.error(req.Method, path, "Not supported in paper trading mode"))
+		}
+		return nil, false, nil
+	}
+
+	var body []byte
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return nil, true, errors.WithStack(err)
+		}
+		body, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, true, errors.WithStack(err)
+		}
+	}
+
+	books, err := p.orderBooks(req.Context(), req.Method, path, body)
+	if err != nil {
+		return nil, true, errors.WithStack(err)
+	}
+
+	p.mu.Lock()
+	result, handled, err := p.route(books, req.Method, path, req.URL.Query(), body)
+	p.mu.Unlock()
+
+	if !handled {
+		return nil, true, errors.WithStack(p.error(req.Method, path, "Not supported in paper trading mode"))
+	}
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			apiErr.Method, apiErr.Endpoint = req.Method, req.URL.Path
+		}
+		return nil, true, errors.WithStack(err)
+	}
+
+	response, err := json.Marshal(result)
+	if err != nil {
+		return nil, true, errors.WithStack(err)
+	}
+	return response, true, nil
+}
+
+// handlesPath reports whether requests to path are served by the paper trader, it is decided
+// before locking so that public requests of the book source pass through.
+func handlesPath(method, path string) bool {
+	if method == http.MethodGet {
+		switch path {
+		case apiFills, apiGetPositions, apiGetWalletBalances, apiGetAccountInformation:
+			return true
+		}
+	}
+	return strings.Split(strings.Trim(path, "/"), "/")[0] == strings.Trim(apiOrders, "/")
+}
+
+// orderBooks fetches the books of the open orders and of the order placed or modified by the request.
+// It runs without p.mu since the book source may send requests through the paper trading client.
+func (p *PaperTrader) orderBooks(ctx context.Context, method, path string, body []byte) (map[string]*OrderBook, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if parts[0] != strings.Trim(apiOrders, "/") {
+		return nil, nil
+	}
+	markets := make(map[string]struct{})
+
+	p.mu.Lock()
+	for _, order := range p.orders {
+		if order.Status == OrderStatusOpen {
+			markets[order.Market] = struct{}{}
+		}
+	}
+	switch {
+	case len(parts) == 1 && method == http.MethodPost:
+		var payload PlaceOrderPayload
+		if err := json.Unmarshal(body, &payload); err == nil && payload.Market != "" {
+			markets[payload.Market] = struct{}{}
+		}
+	case len(parts) > 2 && method == http.MethodPost && parts[len(parts)-1] == "modify":
+		if order := p.findOrder(parts[1:]); order != nil {
+			markets[order.Market] = struct{}{}
+		}
+	}
+	p.mu.Unlock()
+
+	books := make(map[string]*OrderBook, len(markets))
+	for market := range markets {
+		book, err := p.books.OrderBook(ctx, market)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		books[market] = book
+	}
+	return books, nil
+}
+
+func (p *PaperTrader) route(books map[string]*OrderBook, method, path string, query url.Values, body []byte) (interface{}, bool, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case method == http.MethodGet && path == apiFills:
+		return p.listFills(query), true, nil
+	case method == http.MethodGet && path == apiGetPositions:
+		return p.listPositions(), true, nil
+	case method == http.MethodGet && path == apiGetWalletBalances:
+		return p.listBalances(), true, nil
+	case method == http.MethodGet && path == apiGetAccountInformation:
+		return p.accountInformation(), true, nil
+	case parts[0] != strings.Trim(apiOrders, "/"):
+		return nil, false, nil
+	}
+
+	p.matchResting(books)
+
+	switch {
+	case len(parts) == 1 && method == http.MethodGet:
+		return p.listOrders(query.Get("market"), true), true, nil
+	case len(parts) == 1 && method == http.MethodPost:
+		var payload PlaceOrderPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, true, p.error(method, path, "Invalid parameter")
+		}
+		order, err := p.placeOrder(books, payload)
+		return order, true, err
+	case len(parts) == 1 && method == http.MethodDelete:
+		var payload CancelAllOrdersPayload
+		_ = json.Unmarshal(body, &payload)
+		for _, order := range p.orders {
+			if order.Status != OrderStatusClosed && (payload.Market == nil || *payload.Market == order.Market) {
+				p.close(order)
+			}
+		}
+		return "Orders queued for cancelation", true, nil
+	case len(parts) == 2 && parts[1] == "history" && method == http.MethodGet:
+		return p.listOrders(query.Get("market"), false), true, nil
+	case len(parts) < 2:
+		return nil, false, nil
+	}
+
+	order := p.findOrder(parts[1:])
+	if order == nil {
+		return nil, true, p.error(method, path, "Order not found")
+	}
+
+	switch {
+	case method == http.MethodGet:
+		return order, true, nil
+	case method == http.MethodDelete:
+		if order.Status == OrderStatusClosed {
+			return nil, true, p.error(method, path, "Order already closed")
+		}
+		p.close(order)
+		return "Order queued for cancellation", true, nil
+	case method == http.MethodPost && parts[len(parts)-1] == "modify":
+		var payload ModifyOrderPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, true, p.error(method, path, "Invalid parameter")
+		}
+		replacement, err := p.modifyOrder(books, order, payload)
+		return replacement, true, err
+	}
+
+	return nil, false, nil
+}
+
+func (p *PaperTrader) placeOrder(books map[string]*OrderBook, payload PlaceOrderPayload) (*Order, error) {
+	if payload.Side != SideBuy && payload.Side != SideSell {
+		return nil, p.error(http.MethodPost, apiOrders, "Invalid side")
+	}
+	if !payload.Size.IsPositive() {
+		return nil, p.error(http.MethodPost, apiOrders, "Invalid size")
+	}
+	if payload.Type == OrderTypeLimitOrder && !payload.Price.IsPositive() {
+		return nil, p.error(http.MethodPost, apiOrders, "Invalid price")
+	}
+
+	size := payload.Size
+	future := ""
+	if !isSpotMarket(payload.Market) {
+		future = payload.Market
+	}
+	if payload.ReduceOnly {
+		size = p.reducibleSize(payload.Market, payload.Side, size)
+		if !size.IsPositive() {
+			return nil, p.error(http.MethodPost, apiOrders, "Reduce-only order would increase position")
+		}
+	}
+
+	order := &Order{
+		ID:            p.id(),
+		Market:        payload.Market,
+		Type:          payload.Type,
+		Side:          payload.Side,
+		Price:         payload.Price,
+		Size:          size,
+		RemainingSize: size,
+		Status:        OrderStatusOpen,
+		CreatedAt:     p.now().UTC(),
+		ReduceOnly:    payload.ReduceOnly,
+		Ioc:           payload.IOC,
+		PostOnly:      payload.PostOnly,
+		Future:        future,
+		ClientID:      payload.ClientID,
+	}
+
+	err := p.execute(books[order.Market], order)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	p.orders[order.ID] = order
+	result := *order
+	return &result, nil
+}
+
+// modifyOrder cancels order and places a replacement with a new id, as the exchange does.
+func (p *PaperTrader) modifyOrder(books map[string]*OrderBook, order *Order, payload ModifyOrderPayload) (*Order, error) {
+	if order.Status == OrderStatusClosed {
+		return nil, p.error(http.MethodPost, apiOrders, "Order already closed")
+	}
+
+	replacement := *order
+	replacement.ID = p.id()
+	replacement.CreatedAt = p.now().UTC()
+	if payload.Price != nil {
+		replacement.Price = *payload.Price
+	}
+	if payload.Size != nil {
+		if !payload.Size.GreaterThan(order.FilledSize) {
+			return nil, p.error(http.MethodPost, apiOrders, "Invalid size")
+		}
+		replacement.Size = *payload.Size
+	}
+	if payload.ClientID != nil {
+		replacement.ClientID = *payload.ClientID
+	}
+	replacement.Size = replacement.Size.Sub(order.FilledSize)
+	replacement.RemainingSize = replacement.Size
+	replacement.FilledSize = decimal.Zero
+	replacement.AvgFillPrice = decimal.Zero
+
+	// The replacement may use the funds of the original, which is reopened when the replacement fails.
+	status := order.Status
+	p.close(order)
+
+	err := p.execute(books[replacement.Market], &replacement)
+	if err != nil {
+		order.Status = status
+		p.reserve(order)
+		return nil, errors.WithStack(err)
+	}
+
+	p.orders[replacement.ID] = &replacement
+	result := replacement
+	return &result, nil
+}
+
+// execute takes liquidity for a new order and decides whether what is left rests or is cancelled.
+func (p *PaperTrader) execute(book *OrderBook, order *Order) error {
+	if book == nil {
+		return p.error(http.MethodPost, apiOrders, "No orderbook for market")
+	}
+
+	levels := book.Asks
+	if order.Side == SideSell {
+		levels = book.Bids
+	}
+
+	var limit *decimal.Decimal
+	if order.Type != OrderTypeMarketOrder {
+		limit = &order.Price
+	}
+
+	if order.PostOnly && limit != nil && len(levels) > 0 && crosses(order.Side, *limit, levels[0][0]) {
+		order.Status = OrderStatusClosed
+		return nil
+	}
+
+	for _, level := range levels {
+		if !order.RemainingSize.IsPositive() || (limit != nil && !crosses(order.Side, *limit, level[0])) {
+			break
+		}
+		// A level of size zero is a deletion in a replayed update.
+		if !level[1].IsPositive() {
+			continue
+		}
+
+		size := decimal.Min(order.RemainingSize, level[1])
+		err := p.fill(order, size, level[0], LiquidityTaker)
+		if err != nil {
+			if order.FilledSize.IsZero() {
+				return errors.WithStack(err)
+			}
+			break
+		}
+	}
+
+	if order.Type == OrderTypeMarketOrder || order.Ioc {
+		order.Status = OrderStatusClosed
+	}
+	if order.Status == OrderStatusOpen {
+		if err := p.checkFunds(order, order.RemainingSize, order.Price); err != nil {
+			if order.FilledSize.IsZero() {
+				return errors.WithStack(err)
+			}
+			order.Status = OrderStatusClosed
+			return nil
+		}
+		p.reserve(order)
+	}
+	return nil
+}
+
+// matchResting fills resting orders against the crossing size of books, the size taken by
+// earlier orders of a market is not available to later ones. Markets without a book are skipped.
+func (p *PaperTrader) matchResting(books map[string]*OrderBook) {
+	markets := make(map[string][]*Order)
+	for _, order := range p.orders {
+		if order.Status == OrderStatusOpen {
+			markets[order.Market] = append(markets[order.Market], order)
+		}
+	}
+
+	for market, orders := range markets {
+		book, ok := books[market]
+		if !ok {
+			continue
+		}
+
+		taken := map[string]decimal.Decimal{SideBuy: decimal.Zero, SideSell: decimal.Zero}
+		sort.Slice(orders, func(i, j int) bool { return orders[i].ID < orders[j].ID })
+		for _, order := range orders {
+			levels := book.Asks
+			if order.Side == SideSell {
+				levels = book.Bids
+			}
+
+			available := decimal.Zero
+			for _, level := range levels {
+				if !crosses(order.Side, order.Price, level[0]) {
+					break
+				}
+				if level[1].IsPositive() {
+					available = available.Add(level[1])
+				}
+			}
+			size := decimal.Min(order.RemainingSize, available.Sub(taken[order.Side]))
+			if !size.IsPositive() {
+				continue
+			}
+
+			if order.ReduceOnly {
+				size = p.reducibleSize(order.Market, order.Side, size)
+				if !size.IsPositive() {
+					p.close(order)
+					continue
+				}
+			}
+
+			p.release(order)
+			if err := p.fill(order, size, order.Price, LiquidityMaker); err != nil {
+				order.Status = OrderStatusClosed
+				continue
+			}
+			taken[order.Side] = taken[order.Side].Add(size)
+			p.reserve(order)
+		}
+	}
+}
+
+// fill books size at price for order and settles it on the simulated account.
+func (p *PaperTrader) fill(order *Order, size, price decimal.Decimal, liquidity string) error {
+	if !size.IsPositive() {
+		return p.error(http.MethodPost, apiOrders, "Invalid size")
+	}
+
+	err := p.checkFunds(order, size, price)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	feeRate := p.info.TakerFee
+	if liquidity == LiquidityMaker {
+		feeRate = p.info.MakerFee
+	}
+	notional := size.Mul(price)
+	fee := notional.Mul(feeRate)
+
+	filled := order.FilledSize.Add(size)
+	order.AvgFillPrice = order.AvgFillPrice.Mul(order.FilledSize).Add(notional).Div(filled)
+	order.FilledSize = filled
+	order.RemainingSize = order.Size.Sub(filled)
+	if !order.RemainingSize.IsPositive() {
+		order.Status = OrderStatusClosed
+	}
+
+	base, quote := marketCoins(order.Market)
+	if isSpotMarket(order.Market) {
+		if order.Side == SideBuy {
+			p.adjustBalance(base, size)
+			p.adjustBalance(quote, notional.Neg())
+		} else {
+			p.adjustBalance(base, size.Neg())
+			p.adjustBalance(quote, notional)
+		}
+	} else {
+		p.adjustBalance(quote, p.updatePosition(order.Market, order.Side, size, price))
+	}
+	p.adjustBalance(quote, fee.Neg())
+
+	feeValue, _ := fee.Float64()
+	feeRateValue, _ := feeRate.Float64()
+	p.fills = append(p.fills, Fill{
+		Fee:           feeValue,
+		FeeCurrency:   quote,
+		FeeRate:       feeRateValue,
+		Future:        order.Future,
+		ID:            p.id(),
+		Liquidity:     liquidity,
+		Market:        order.Market,
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		OrderID:       order.ID,
+		TradeID:       p.id(),
+		Price:         price,
+		Side:          order.Side,
+		Size:          size,
+		Time:          FTXTime{Time: p.now().UTC()},
+		Type:          "order",
+	})
+
+	return nil
+}
+
+// checkFunds only applies to spot markets, futures are not margin checked.
+func (p *PaperTrader) checkFunds(order *Order, size, price decimal.Decimal) error {
+	if !isSpotMarket(order.Market) {
+		return nil
+	}
+
+	coin, needed := requiredFunds(order, size, price)
+	balance, ok := p.balances[coin]
+	if !ok || balance.Free.LessThan(needed) {
+		return p.error(http.MethodPost, apiOrders, "Not enough balances")
+	}
+	return nil
+}
+
+// reserve moves the funds a resting spot order needs out of Free, so that other orders cannot commit them.
+func (p *PaperTrader) reserve(order *Order) {
+	if !isSpotMarket(order.Market) || order.Status != OrderStatusOpen {
+		return
+	}
+
+	coin, needed := requiredFunds(order, order.RemainingSize, order.Price)
+	if balance, ok := p.balances[coin]; ok {
+		balance.Free = balance.Free.Sub(needed)
+		p.reserved[order.ID] = needed
+	}
+}
+
+// release returns the funds reserved by order to Free.
+func (p *PaperTrader) release(order *Order) {
+	reserved, ok := p.reserved[order.ID]
+	if !ok {
+		return
+	}
+
+	coin, _ := requiredFunds(order, decimal.Zero, decimal.Zero)
+	if balance, ok := p.balances[coin]; ok {
+		balance.Free = balance.Free.Add(reserved)
+	}
+	delete(p.reserved, order.ID)
+}
+
+func (p *PaperTrader) close(order *Order) {
+	order.Status = OrderStatusClosed
+	p.release(order)
+}
+
+// updatePosition applies a futures fill to the position and returns the realized PnL.
+func (p *PaperTrader) updatePosition(future, side string, size, price decimal.Decimal) decimal.Decimal {
+	position, ok := p.positions[future]
+	if !ok {
+		position = &Position{Future: future}
+		p.positions[future] = position
+	}
+
+	signed := size
+	if side == SideSell {
+		signed = size.Neg()
+	}
+	netSize := position.NetSize.Add(signed)
+
+	realized := decimal.Zero
+	switch {
+	case position.NetSize.IsZero() || position.NetSize.Sign() == signed.Sign():
+		position.EntryPrice = position.NetSize.Abs().Mul(position.EntryPrice).Add(size.Mul(price)).Div(netSize.Abs())
+	default:
+		closed := decimal.Min(size, position.NetSize.Abs())
+		realized = closed.Mul(price.Sub(position.EntryPrice))
+		if position.NetSize.IsNegative() {
+			realized = realized.Neg()
+		}
+		if netSize.IsZero() {
+			position.EntryPrice = decimal.Zero
+		} else if netSize.Sign() != position.NetSize.Sign() {
+			position.EntryPrice = price
+		}
+	}
+
+	position.NetSize = netSize
+	position.Size = netSize.Abs()
+	position.Cost = netSize.Mul(position.EntryPrice)
+	position.RealizedPnl = position.RealizedPnl.Add(realized)
+	position.Side = SideBuy
+	if netSize.IsNegative() {
+		position.Side = SideSell
+	}
+
+	return realized
+}
+
+// reducibleSize clips size to what reduces the current position of market.
+func (p *PaperTrader) reducibleSize(market, side string, size decimal.Decimal) decimal.Decimal {
+	netSize := decimal.Zero
+	if isSpotMarket(market) {
+		base, _ := marketCoins(market)
+		if balance, ok := p.balances[base]; ok {
+			netSize = balance.Total
+		}
+	} else if position, ok := p.positions[market]; ok {
+		netSize = position.NetSize
+	}
+
+	if (side == SideBuy && !netSize.IsNegative()) || (side == SideSell && !netSize.IsPositive()) {
+		return decimal.Zero
+	}
+	return decimal.Min(size, netSize.Abs())
+}
+
+func (p *PaperTrader) adjustBalance(coin string, amount decimal.Decimal) {
+	balance, ok := p.balances[coin]
+	if !ok {
+		balance = &Balance{Coin: coin}
+		p.balances[coin] = balance
+	}
+	balance.Free = balance.Free.Add(amount)
+	balance.Total = balance.Total.Add(amount)
+}
+
+func (p *PaperTrader) findOrder(parts []string) *Order {
+	if parts[0] == "by_client_id" && len(parts) > 1 {
+		var found *Order
+		for _, order := range p.orders {
+			if order.ClientID == parts[1] && (found == nil || order.ID > found.ID) {
+				found = order
+			}
+		}
+		return found
+	}
+
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil
+	}
+	return p.orders[id]
+}
+
+func (p *PaperTrader) listOrders(market string, openOnly bool) []Order {
+	result := make([]Order, 0)
+	for _, order := range p.orders {
+		if (market != "" && order.Market != market) || (openOnly && order.Status == OrderStatusClosed) {
+			continue
+		}
+		result = append(result, *order)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	return result
+}
+
+func (p *PaperTrader) listFills(query url.Values) []Fill {
+	result := make([]Fill, 0, len(p.fills))
+	for i := len(p.fills) - 1; i >= 0; i-- {
+		fill := p.fills[i]
+		if market := query.Get("market"); market != "" && fill.Market != market {
+			continue
+		}
+		if orderID := query.Get("orderId"); orderID != "" && fmt.Sprint(fill.OrderID) != orderID {
+			continue
+		}
+		result = append(result, fill)
+	}
+	return result
+}
+
+func (p *PaperTrader) listPositions() []Position {
+	result := make([]Position, 0, len(p.positions))
+	for _, position := range p.positions {
+		result = append(result, *position)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Future < result[j].Future })
+	return result
+}
+
+func (p *PaperTrader) listBalances() []Balance {
+	result := make([]Balance, 0, len(p.balances))
+	for _, balance := range p.balances {
+		result = append(result, *balance)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Coin < result[j].Coin })
+	return result
+}
+
+func (p *PaperTrader) accountInformation() AccountInformation {
+	info := p.info
+	info.Positions = p.listPositions()
+
+	collateral := decimal.Zero
+	if balance, ok := p.balances[paperQuoteCurrency]; ok {
+		collateral = balance.Total
+	}
+	info.Collateral = collateral
+	info.FreeCollateral = collateral
+	info.TotalAccountValue = collateral
+	return info
+}
+
+func (p *PaperTrader) error(method, path, message string) *APIError {
+	return &APIError{
+		StatusCode: http.StatusBadRequest,
+		Message:    message,
+		Method:     method,
+		Endpoint:   path,
+	}
+}
+
+func (p *PaperTrader) id() int64 {
+	p.nextID++
+	return p.nextID
+}
+
+// crosses reports whether an order at price trades against a resting order at bookPrice.
+func crosses(side string, price, bookPrice decimal.Decimal) bool {
+	if side == SideBuy {
+		return bookPrice.LessThanOrEqual(price)
+	}
+	return bookPrice.GreaterThanOrEqual(price)
+}
+
+func isSpotMarket(market string) bool {
+	return strings.Contains(market, "/")
+}
+
+// requiredFunds returns the coin and amount a spot order of size at price spends.
+func requiredFunds(order *Order, size, price decimal.Decimal) (string, decimal.Decimal) {
+	base, quote := marketCoins(order.Market)
+	if order.Side == SideBuy {
+		return quote, size.Mul(price)
+	}
+	return base, size
+}
+
+// marketCoins returns the coins a fill in market settles in.
+func marketCoins(market string) (base, quote string) {
+	if i := strings.Index(market, "/"); i >= 0 {
+		return market[:i], market[i+1:]
+	}
+	return market, paperQuoteCurrency
+}
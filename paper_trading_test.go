@@ -0,0 +1,237 @@
+package goftx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/wizpacekorea/goftx"
+	"github.com/wizpacekorea/goftx/goftxtest"
+)
+
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func book(asks, bids [][]string) goftx.OrderBook {
+	levels := func(rows [][]string) [][]decimal.Decimal {
+		result := make([][]decimal.Decimal, 0, len(rows))
+		for _, row := range rows {
+			result = append(result, []decimal.Decimal{dec(row[0]), dec(row[1])})
+		}
+		return result
+	}
+	return goftx.OrderBook{Asks: levels(asks), Bids: levels(bids)}
+}
+
+// newPaperClient returns a paper trading client that reads its books through itself from the fake server.
+func newPaperClient(t *testing.T, balances ...goftx.Balance) (*goftxtest.Server, *goftx.Client, *goftx.PaperTrader) {
+	server := goftxtest.NewServer()
+	t.Cleanup(server.Close)
+	server.AddMarket(goftx.Market{Name: "BTC/USD", Type: goftx.MarketTypeSpot})
+
+	var client *goftx.Client
+	books := goftx.OrderBookSourceFunc(func(ctx context.Context, market string) (*goftx.OrderBook, error) {
+		return goftx.LiveOrderBooks(client, 20).OrderBook(ctx, market)
+	})
+	trader := goftx.NewPaperTrader(books, goftx.AccountInformation{
+		MakerFee: dec("0.0002"),
+		TakerFee: dec("0.0007"),
+	}, balances...)
+	client = server.Client(goftx.WithPaperTrading(trader))
+
+	return server, client, trader
+}
+
+func balanceOf(trader *goftx.PaperTrader, coin string) goftx.Balance {
+	for _, balance := range trader.Balances() {
+		if balance.Coin == coin {
+			return balance
+		}
+	}
+	return goftx.Balance{Coin: coin}
+}
+
+func TestPaperTradingBooksFromSameClient(t *testing.T) {
+	server, client, trader := newPaperClient(t, goftx.Balance{Coin: "USD", Free: dec("10000"), Total: dec("10000")})
+	server.SetOrderBook("BTC/USD", book([][]string{{"100", "1"}, {"101", "2"}}, [][]string{{"99", "1"}}))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.PlaceOrder(&goftx.PlaceOrderPayload{
+			Market: "BTC/USD",
+			Side:   goftx.SideBuy,
+			Type:   goftx.OrderTypeMarketOrder,
+			Size:   dec("2"),
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("market order did not return")
+	}
+
+	// 1 @ 100 + 1 @ 101 taking at 7 bps.
+	if got := balanceOf(trader, "BTC").Total; !got.Equal(dec("2")) {
+		t.Errorf("BTC total = %s, want 2", got)
+	}
+	if got := balanceOf(trader, "USD").Total; !got.Equal(dec("9798.8593")) {
+		t.Errorf("USD total = %s, want 9798.8593", got)
+	}
+}
+
+func TestPaperTradingReservesRestingFunds(t *testing.T) {
+	server, client, trader := newPaperClient(t, goftx.Balance{Coin: "USD", Free: dec("1000"), Total: dec("1000")})
+	server.SetOrderBook("BTC/USD", book([][]string{{"110", "5"}}, [][]string{{"90", "5"}}))
+
+	first, err := client.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market: "BTC/USD",
+		Side:   goftx.SideBuy,
+		Type:   goftx.OrderTypeLimitOrder,
+		Price:  dec("100"),
+		Size:   dec("8"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := balanceOf(trader, "USD"); !got.Free.Equal(dec("200")) || !got.Total.Equal(dec("1000")) {
+		t.Fatalf("USD after resting order = %+v, want 200 free of 1000", got)
+	}
+
+	_, err = client.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market: "BTC/USD",
+		Side:   goftx.SideBuy,
+		Type:   goftx.OrderTypeLimitOrder,
+		Price:  dec("100"),
+		Size:   dec("8"),
+	})
+	if err == nil {
+		t.Fatal("second order committing the same balance was accepted")
+	}
+
+	_, err = client.ModifyOrder(&goftx.ModifyOrderPayload{Size: decimalPtr(dec("5"))}, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := balanceOf(trader, "USD").Free; !got.Equal(dec("500")) {
+		t.Errorf("USD free after modify = %s, want 500", got)
+	}
+
+	orders, err := client.GetOpenOrders("BTC/USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, order := range orders {
+		err = client.CancelOrder(order.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := balanceOf(trader, "USD").Free; !got.Equal(dec("1000")) {
+		t.Errorf("USD free after cancel = %s, want 1000", got)
+	}
+}
+
+func TestPaperTradingRestingFillCappedAtBookSize(t *testing.T) {
+	server, client, trader := newPaperClient(t, goftx.Balance{Coin: "USD", Free: dec("10000"), Total: dec("10000")})
+	server.SetOrderBook("BTC/USD", book([][]string{{"110", "5"}}, [][]string{{"90", "5"}}))
+
+	order, err := client.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market: "BTC/USD",
+		Side:   goftx.SideBuy,
+		Type:   goftx.OrderTypeLimitOrder,
+		Price:  dec("100"),
+		Size:   dec("10"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.SetOrderBook("BTC/USD", book([][]string{{"99", "1"}, {"100", "2"}, {"101", "50"}}, [][]string{{"90", "5"}}))
+	err = trader.Match(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.SetOrderBook("BTC/USD", book([][]string{{"110", "5"}}, [][]string{{"90", "5"}}))
+
+	order, err = client.GetOrder(order.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !order.FilledSize.Equal(dec("3")) || order.Status != goftx.OrderStatusOpen {
+		t.Fatalf("order filled %s with status %s, want 3 and open", order.FilledSize, order.Status)
+	}
+
+	// 3 @ 100 making at 2 bps, the other 7 @ 100 stay reserved.
+	fills := trader.Fills()
+	if len(fills) != 1 || fills[0].Liquidity != goftx.LiquidityMaker || fills[0].Fee != 0.06 {
+		t.Fatalf("fills = %+v, want a single maker fill paying 0.06", fills)
+	}
+	if got := balanceOf(trader, "USD"); !got.Total.Equal(dec("9699.94")) || !got.Free.Equal(dec("8999.94")) {
+		t.Errorf("USD = %+v, want 9699.94 total and 8999.94 free", got)
+	}
+}
+
+func TestPaperTradingFailedModifyKeepsOrder(t *testing.T) {
+	server, client, trader := newPaperClient(t, goftx.Balance{Coin: "USD", Free: dec("1000"), Total: dec("1000")})
+	server.SetOrderBook("BTC/USD", book([][]string{{"110", "5"}}, [][]string{{"90", "5"}}))
+
+	order, err := client.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market: "BTC/USD",
+		Side:   goftx.SideBuy,
+		Type:   goftx.OrderTypeLimitOrder,
+		Price:  dec("100"),
+		Size:   dec("8"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.ModifyOrder(&goftx.ModifyOrderPayload{Size: decimalPtr(dec("20"))}, order.ID)
+	if err == nil {
+		t.Fatal("modify beyond the balance succeeded")
+	}
+
+	order, err = client.GetOrder(order.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != goftx.OrderStatusOpen || !order.RemainingSize.Equal(dec("8")) {
+		t.Errorf("order after failed modify = %s with %s remaining, want open with 8", order.Status, order.RemainingSize)
+	}
+	if got := balanceOf(trader, "USD").Free; !got.Equal(dec("200")) {
+		t.Errorf("USD free after failed modify = %s, want 200", got)
+	}
+}
+
+func TestPaperTradingSkipsDeletedLevels(t *testing.T) {
+	server, client, trader := newPaperClient(t, goftx.Balance{Coin: "USD", Free: dec("1000"), Total: dec("1000")})
+	server.SetOrderBook("BTC/USD", book([][]string{{"100", "0"}, {"101", "1"}}, [][]string{{"90", "5"}}))
+
+	order, err := client.PlaceOrder(&goftx.PlaceOrderPayload{
+		Market: "BTC/USD",
+		Side:   goftx.SideBuy,
+		Type:   goftx.OrderTypeMarketOrder,
+		Size:   dec("1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !order.FilledSize.Equal(dec("1")) || !order.AvgFillPrice.Equal(dec("101")) {
+		t.Errorf("order filled %s at %s, want 1 at 101", order.FilledSize, order.AvgFillPrice)
+	}
+	if fills := trader.Fills(); len(fills) != 1 {
+		t.Errorf("fills = %+v, want a single fill", fills)
+	}
+}
+
+func decimalPtr(value decimal.Decimal) *decimal.Decimal {
+	return &value
+}
@@ -0,0 +1,158 @@
+package goftx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type RequestClass int
+
+const (
+	RequestClassGeneral RequestClass = iota
+	// RequestClassOrder covers placing, modifying and cancelling orders and trigger orders.
+	RequestClassOrder
+)
+
+// RateLimiter is consulted by the client before every REST request is sent.
+// Wait blocks until the request may go out or returns an error to abort it.
+type RateLimiter interface {
+	Wait(ctx context.Context, subAccount string, class RequestClass) error
+}
+
+// RateLimit allows Requests requests per Interval, with bursts of up to Requests.
+type RateLimit struct {
+	Requests int
+	Interval time.Duration
+}
+
+var (
+	DefaultGeneralRateLimit = RateLimit{Requests: 30, Interval: time.Second}
+	DefaultOrderRateLimit   = RateLimit{Requests: 10, Interval: time.Second}
+)
+
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// TokenBucketLimiter keeps a token bucket per subaccount and request class.
+// In fail fast mode a request that would have to wait fails with ErrRateLimited instead.
+type TokenBucketLimiter struct {
+	mu       sync.Mutex
+	limits   map[RequestClass]RateLimit
+	failFast bool
+	buckets  map[bucketKey]*tokenBucket
+}
+
+type bucketKey struct {
+	subAccount string
+	class      RequestClass
+}
+
+type tokenBucket struct {
+	capacity float64
+	tokens   float64
+	rate     float64 // tokens per second
+	updated  time.Time
+}
+
+func NewTokenBucketLimiter(general, order RateLimit, failFast bool) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		limits: map[RequestClass]RateLimit{
+			RequestClassGeneral: general,
+			RequestClassOrder:   order,
+		},
+		failFast: failFast,
+		buckets:  make(map[bucketKey]*tokenBucket),
+	}
+}
+
+func (l *TokenBucketLimiter) Wait(ctx context.Context, subAccount string, class RequestClass) error {
+	l.mu.Lock()
+	bucket := l.bucket(subAccount, class)
+	if bucket == nil {
+		l.mu.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	bucket.refill(now)
+	if l.failFast && bucket.tokens < 1 {
+		l.mu.Unlock()
+		return errors.Wrap(ErrRateLimited, "client side rate limit exceeded")
+	}
+
+	bucket.tokens--
+	delay := bucket.delay()
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		bucket.tokens++
+		l.mu.Unlock()
+		return errors.WithStack(ctx.Err())
+	}
+}
+
+func (l *TokenBucketLimiter) bucket(subAccount string, class RequestClass) *tokenBucket {
+	key := bucketKey{subAccount: subAccount, class: class}
+	if bucket, ok := l.buckets[key]; ok {
+		return bucket
+	}
+
+	limit, ok := l.limits[class]
+	if !ok || limit.Requests <= 0 || limit.Interval <= 0 {
+		return nil
+	}
+
+	bucket := &tokenBucket{
+		capacity: float64(limit.Requests),
+		tokens:   float64(limit.Requests),
+		rate:     float64(limit.Requests) / limit.Interval.Seconds(),
+		updated:  time.Now(),
+	}
+	l.buckets[key] = bucket
+	return bucket
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.updated).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.updated = now
+}
+
+// delay is how long the last taken token has to be waited for.
+func (b *tokenBucket) delay() time.Duration {
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func requestClass(req *http.Request) RequestClass {
+	if req.Method == http.MethodGet {
+		return RequestClassGeneral
+	}
+	if strings.Contains(req.URL.Path, apiOrders) || strings.Contains(req.URL.Path, apiTriggerOrders) {
+		return RequestClassOrder
+	}
+	return RequestClassGeneral
+}
@@ -0,0 +1,165 @@
+package goftx
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy retries failed requests with exponential backoff and jitter.
+//
+// GET requests are retried on network errors and on retryable API errors (5xx, rate limits, invalid nonce).
+// Other methods are only retried when the exchange cannot have acted on them: the connection
+// could not be established, or the request was rejected for its rate limit or nonce.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt, values below 2 disable retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// OnAttempt is called after every attempt, including the first and the last, with its outcome.
+	// Err is nil when the attempt succeeded, Final is set when no retry follows and Delay is not set.
+	OnAttempt func(attempt RetryAttempt)
+	// OnRetry is called before waiting for each retry, it only sees the failed attempts that are retried.
+	OnRetry func(attempt RetryAttempt)
+}
+
+type RetryAttempt struct {
+	// Attempt is the number of the attempt, starting at 1.
+	Attempt  int
+	Method   string
+	Endpoint string
+	Err      error
+	// Delay is the wait before the next attempt, only set for OnRetry.
+	Delay time.Duration
+	// Final is set when the attempt is the last one of the request, only set for OnAttempt.
+	Final bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(req *http.Request, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts || req.Context().Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if isIdempotent(req.Method) {
+			return apiErr.Retryable()
+		}
+		return apiErr.Is(ErrRateLimited) || apiErr.Is(ErrInvalidNonce)
+	}
+
+	// Errors raised before sending, like a fail fast rate limiter, are not network errors.
+	if errors.Is(err, ErrRateLimited) {
+		return false
+	}
+
+	if isIdempotent(req.Method) {
+		return true
+	}
+	return isDialError(err)
+}
+
+func (p *RetryPolicy) observe(req *http.Request, err error, attempt int, final bool) {
+	if p == nil || p.OnAttempt == nil {
+		return
+	}
+
+	p.OnAttempt(RetryAttempt{
+		Attempt:  attempt,
+		Method:   req.Method,
+		Endpoint: req.URL.Path,
+		Err:      err,
+		Final:    final,
+	})
+}
+
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	delay := p.BaseDelay << uint(attempt-1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retry waits for the backoff of attempt and returns a copy of req ready to be sent again.
+func (c *Client) retry(req *http.Request, err error, attempt int) (*http.Request, error) {
+	delay := c.retryPolicy.delay(attempt)
+	if c.retryPolicy.OnRetry != nil {
+		c.retryPolicy.OnRetry(RetryAttempt{
+			Attempt:  attempt,
+			Method:   req.Method,
+			Endpoint: req.URL.Path,
+			Err:      err,
+			Delay:    delay,
+		})
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-req.Context().Done():
+		return nil, errors.WithStack(req.Context().Err())
+	}
+
+	retryReq := req.Clone(req.Context())
+
+	var body []byte
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		body, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		retryReq.Body, _ = req.GetBody()
+	}
+
+	if req.Header.Get(c.header(signHeader)) != "" {
+		c.signRequest(retryReq, body)
+	}
+
+	return retryReq, nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
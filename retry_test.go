@@ -0,0 +1,74 @@
+package goftx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wizpacekorea/goftx"
+)
+
+// newFlakyServer fails the first failures requests with a 500 and then serves an empty market list.
+func newFlakyServer(t *testing.T, failures int32) *httptest.Server {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"success":false,"error":"Internal error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"result":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRetryPolicyObservesEveryAttempt(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int32
+		wantErrs []bool
+		wantErr  bool
+	}{
+		{name: "succeeds after retries", failures: 2, wantErrs: []bool{true, true, false}},
+		{name: "fails once retries run out", failures: 10, wantErrs: []bool{true, true, true}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newFlakyServer(t, test.failures)
+
+			var attempts, retries []goftx.RetryAttempt
+			client := goftx.New(
+				goftx.WithBaseURL(server.URL),
+				goftx.WithRateLimiter(nil),
+				goftx.WithRetryPolicy(goftx.RetryPolicy{
+					MaxAttempts: 3,
+					BaseDelay:   time.Millisecond,
+					OnAttempt:   func(attempt goftx.RetryAttempt) { attempts = append(attempts, attempt) },
+					OnRetry:     func(attempt goftx.RetryAttempt) { retries = append(retries, attempt) },
+				}),
+			)
+
+			_, err := client.GetMarkets()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("error = %v, want error %v", err, test.wantErr)
+			}
+
+			if len(attempts) != len(test.wantErrs) {
+				t.Fatalf("observed %d attempts, want %d", len(attempts), len(test.wantErrs))
+			}
+			for i, attempt := range attempts {
+				final := i == len(attempts)-1
+				if attempt.Attempt != i+1 || (attempt.Err != nil) != test.wantErrs[i] || attempt.Final != final {
+					t.Errorf("attempt %d = %+v, want error %v and final %v", i+1, attempt, test.wantErrs[i], final)
+				}
+			}
+			if len(retries) != len(attempts)-1 {
+				t.Errorf("OnRetry called %d times, want %d", len(retries), len(attempts)-1)
+			}
+		})
+	}
+}
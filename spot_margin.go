@@ -1,6 +1,7 @@
 package goftx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -44,7 +45,14 @@ type BorrowHistory struct {
 	Time time.Time       `json:"time"`
 }
 
-type LendingHistory BorrowHistory
+// LendingHistory is the interest received for lending Size at Rate in the hour starting at Time.
+type LendingHistory struct {
+	Coin     string          `json:"coin"`
+	Proceeds decimal.Decimal `json:"proceeds"`
+	Rate     decimal.Decimal `json:"rate"`
+	Size     decimal.Decimal `json:"size"`
+	Time     time.Time       `json:"time"`
+}
 
 type LendingOffer struct {
 	Coin string          `json:"coin"`
@@ -82,10 +90,14 @@ type SpotMargin struct {
 }
 
 func (s *SpotMargin) GetBorrowRates() ([]BorrowRate, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetBorrowRatesWithContext(context.Background())
+}
+
+func (s *SpotMargin) GetBorrowRatesWithContext(ctx context.Context) ([]BorrowRate, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiBorrowRates),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiBorrowRates),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -106,10 +118,14 @@ func (s *SpotMargin) GetBorrowRates() ([]BorrowRate, error) {
 }
 
 func (s *SpotMargin) GetLendingRates() ([]LendingRate, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetLendingRatesWithContext(context.Background())
+}
+
+func (s *SpotMargin) GetLendingRatesWithContext(ctx context.Context) ([]LendingRate, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiLendingRates),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiLendingRates),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -130,10 +146,14 @@ func (s *SpotMargin) GetLendingRates() ([]LendingRate, error) {
 }
 
 func (s *SpotMargin) GetDailyBorrowedAmounts() ([]BorrowSummary, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetDailyBorrowedAmountsWithContext(context.Background())
+}
+
+func (s *SpotMargin) GetDailyBorrowedAmountsWithContext(ctx context.Context) ([]BorrowSummary, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiBorrowSummary),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiBorrowSummary),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -154,14 +174,18 @@ func (s *SpotMargin) GetDailyBorrowedAmounts() ([]BorrowSummary, error) {
 }
 
 func (s *SpotMargin) GetMarketInfo(market string) ([]GetSpotMarginMarketInfoResponse, error) {
+	return s.GetMarketInfoWithContext(context.Background(), market)
+}
+
+func (s *SpotMargin) GetMarketInfoWithContext(ctx context.Context, market string) ([]GetSpotMarginMarketInfoResponse, error) {
 	queryParams := map[string]string{
 		"market": market,
 	}
 
-	request, err := s.client.prepareRequest(Request{
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiMarketInfo),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiMarketInfo),
 		Params: queryParams,
 	})
 	if err != nil {
@@ -183,10 +207,14 @@ func (s *SpotMargin) GetMarketInfo(market string) ([]GetSpotMarginMarketInfoResp
 }
 
 func (s *SpotMargin) GetBorrowHistory() ([]BorrowHistory, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetBorrowHistoryWithContext(context.Background())
+}
+
+func (s *SpotMargin) GetBorrowHistoryWithContext(ctx context.Context) ([]BorrowHistory, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiBorrowHistory),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiBorrowHistory),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -207,10 +235,14 @@ func (s *SpotMargin) GetBorrowHistory() ([]BorrowHistory, error) {
 }
 
 func (s *SpotMargin) GetLendingHistory() ([]LendingHistory, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetLendingHistoryWithContext(context.Background())
+}
+
+func (s *SpotMargin) GetLendingHistoryWithContext(ctx context.Context) ([]LendingHistory, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiLendingHistory),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiLendingHistory),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -231,10 +263,14 @@ func (s *SpotMargin) GetLendingHistory() ([]LendingHistory, error) {
 }
 
 func (s *SpotMargin) GetLendingOffers() ([]LendingOffer, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetLendingOffersWithContext(context.Background())
+}
+
+func (s *SpotMargin) GetLendingOffersWithContext(ctx context.Context) ([]LendingOffer, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiLendingOffers),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiLendingOffers),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -255,10 +291,14 @@ func (s *SpotMargin) GetLendingOffers() ([]LendingOffer, error) {
 }
 
 func (s *SpotMargin) GetLendingInfo() ([]LendingInfo, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetLendingInfoWithContext(context.Background())
+}
+
+func (s *SpotMargin) GetLendingInfoWithContext(ctx context.Context) ([]LendingInfo, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiLendingInfo),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiLendingInfo),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -279,15 +319,19 @@ func (s *SpotMargin) GetLendingInfo() ([]LendingInfo, error) {
 }
 
 func (s *SpotMargin) SubmitLendingOffer(payload *LendingOfferPayload) error {
+	return s.SubmitLendingOfferWithContext(context.Background(), payload)
+}
+
+func (s *SpotMargin) SubmitLendingOfferWithContext(ctx context.Context, payload *LendingOfferPayload) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	request, err := s.client.prepareRequest(Request{
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiLendingOffers),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiLendingOffers),
 		Body:   body,
 	})
 	if err != nil {
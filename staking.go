@@ -0,0 +1,342 @@
+package goftx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type Stake struct {
+	ID        int64           `json:"id"`
+	Coin      string          `json:"coin"`
+	Size      decimal.Decimal `json:"size"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type UnstakeRequest struct {
+	ID        int64           `json:"id"`
+	Coin      string          `json:"coin"`
+	Size      decimal.Decimal `json:"size"`
+	Status    string          `json:"status"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UnlockAt  time.Time       `json:"unlockAt"`
+}
+
+type StakeBalance struct {
+	Coin               string          `json:"coin"`
+	Staked             decimal.Decimal `json:"staked"`
+	ScheduledToUnstake decimal.Decimal `json:"scheduledToUnstake"`
+	LifetimeRewards    decimal.Decimal `json:"lifetimeRewards"`
+}
+
+type StakingReward struct {
+	ID     int64           `json:"id"`
+	Coin   string          `json:"coin"`
+	Size   decimal.Decimal `json:"size"`
+	Status string          `json:"status"`
+	Time   time.Time       `json:"time"`
+}
+
+type GetStakingRewardsParams struct {
+	StartTime *int `json:"start_time"`
+	EndTime   *int `json:"end_time"`
+}
+
+type StakePayload struct {
+	Coin string          `json:"coin"`
+	Size decimal.Decimal `json:"size"`
+}
+
+// StakingYield is the yield realised by a coin over a period.
+// Yield is Rewards relative to the currently staked balance and AnnualizedYield extrapolates it to a year.
+type StakingYield struct {
+	Coin            string
+	Rewards         decimal.Decimal
+	Staked          decimal.Decimal
+	Yield           decimal.Decimal
+	AnnualizedYield decimal.Decimal
+}
+
+const (
+	apiStakes          = "/staking/stakes"
+	apiCreateStake     = "/srm_stakes/stakes"
+	apiUnstakeRequests = "/staking/unstake_requests"
+	apiUnstakeRequest  = "/staking/unstake_requests/%d"
+	apiStakeBalances   = "/staking/balances"
+	apiStakingRewards  = "/staking/staking_rewards"
+)
+
+type Staking struct {
+	client *Client
+}
+
+func (s *Staking) GetStakes() ([]Stake, error) {
+	return s.GetStakesWithContext(context.Background())
+}
+
+func (s *Staking) GetStakesWithContext(ctx context.Context) ([]Stake, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiStakes),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := s.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []Stake
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (s *Staking) CreateStake(payload *StakePayload) (*Stake, error) {
+	return s.CreateStakeWithContext(context.Background(), payload)
+}
+
+func (s *Staking) CreateStakeWithContext(ctx context.Context, payload *StakePayload) (*Stake, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := s.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiCreateStake),
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := s.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *Stake
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (s *Staking) GetUnstakeRequests() ([]UnstakeRequest, error) {
+	return s.GetUnstakeRequestsWithContext(context.Background())
+}
+
+func (s *Staking) GetUnstakeRequestsWithContext(ctx context.Context) ([]UnstakeRequest, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiUnstakeRequests),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := s.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []UnstakeRequest
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (s *Staking) CreateUnstakeRequest(payload *StakePayload) (*UnstakeRequest, error) {
+	return s.CreateUnstakeRequestWithContext(context.Background(), payload)
+}
+
+func (s *Staking) CreateUnstakeRequestWithContext(ctx context.Context, payload *StakePayload) (*UnstakeRequest, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := s.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiUnstakeRequests),
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := s.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *UnstakeRequest
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (s *Staking) CancelUnstakeRequest(requestID int64) error {
+	return s.CancelUnstakeRequestWithContext(context.Background(), requestID)
+}
+
+func (s *Staking) CancelUnstakeRequestWithContext(ctx context.Context, requestID int64) error {
+	request, err := s.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodDelete,
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, fmt.Sprintf(apiUnstakeRequest, requestID)),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = s.client.do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (s *Staking) GetStakeBalances() ([]StakeBalance, error) {
+	return s.GetStakeBalancesWithContext(context.Background())
+}
+
+func (s *Staking) GetStakeBalancesWithContext(ctx context.Context) ([]StakeBalance, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiStakeBalances),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := s.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []StakeBalance
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (s *Staking) GetStakingRewards(params *GetStakingRewardsParams) ([]StakingReward, error) {
+	return s.GetStakingRewardsWithContext(context.Background(), params)
+}
+
+func (s *Staking) GetStakingRewardsWithContext(ctx context.Context, params *GetStakingRewardsParams) ([]StakingReward, error) {
+	queryParams, err := PrepareQueryParams(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := s.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiStakingRewards),
+		Params: queryParams,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := s.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []StakingReward
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+// GetRealizedYield sums the staking rewards received between startTime and endTime by coin
+// and relates them to the staked balance. The rewards are paged through the whole period.
+// The current balance is used as principal, so the yield is skewed when stakes changed during the period.
+func (s *Staking) GetRealizedYield(ctx context.Context, startTime, endTime time.Time) ([]StakingYield, error) {
+	start, end := int(startTime.Unix()), int(endTime.Unix())
+	var rewards []StakingReward
+	it := s.IterateStakingRewards(ctx, &GetStakingRewardsParams{StartTime: &start, EndTime: &end})
+	for it.Next() {
+		rewards = append(rewards, it.StakingReward())
+	}
+	if err := it.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	balances, err := s.GetStakeBalancesWithContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return realizedYield(rewards, balances, startTime, endTime), nil
+}
+
+func realizedYield(rewards []StakingReward, balances []StakeBalance, startTime, endTime time.Time) []StakingYield {
+	yields := make(map[string]*StakingYield)
+	for _, balance := range balances {
+		yields[balance.Coin] = &StakingYield{Coin: balance.Coin, Staked: balance.Staked}
+	}
+	for _, reward := range rewards {
+		if reward.Time.Before(startTime) || reward.Time.After(endTime) {
+			continue
+		}
+		if _, ok := yields[reward.Coin]; !ok {
+			yields[reward.Coin] = &StakingYield{Coin: reward.Coin}
+		}
+		yields[reward.Coin].Rewards = yields[reward.Coin].Rewards.Add(reward.Size)
+	}
+
+	year := decimal.NewFromInt(int64(365 * 24 * time.Hour))
+	period := decimal.NewFromInt(int64(endTime.Sub(startTime)))
+
+	result := make([]StakingYield, 0, len(yields))
+	for _, yield := range yields {
+		if yield.Staked.IsPositive() {
+			yield.Yield = yield.Rewards.Div(yield.Staked)
+			if period.IsPositive() {
+				yield.AnnualizedYield = yield.Yield.Mul(year).Div(period)
+			}
+		}
+		result = append(result, *yield)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Coin < result[j].Coin
+	})
+
+	return result
+}
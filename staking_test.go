@@ -0,0 +1,80 @@
+package goftx_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/wizpacekorea/goftx"
+)
+
+// newStakingServer serves the rewards newest first in pages of two, the way the exchange caps its pages.
+func newStakingServer(t *testing.T, rewards []goftx.StakingReward) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result interface{}
+		switch r.URL.Path {
+		case "/staking/staking_rewards":
+			requests++
+			endTime, err := strconv.ParseInt(r.URL.Query().Get("end_time"), 10, 64)
+			if err != nil {
+				endTime = time.Now().Unix()
+			}
+			var page []goftx.StakingReward
+			for _, reward := range rewards {
+				if reward.Time.Unix() <= endTime && len(page) < 2 {
+					page = append(page, reward)
+				}
+			}
+			result = page
+		case "/staking/balances":
+			result = []goftx.StakeBalance{{Coin: "SRM", Staked: dec("1000")}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		data, _ := json.Marshal(result)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "result": json.RawMessage(data)})
+	}))
+	t.Cleanup(server.Close)
+
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Time.After(rewards[j].Time)
+	})
+	return server, &requests
+}
+
+func TestGetRealizedYieldPagesRewards(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var rewards []goftx.StakingReward
+	for i := 0; i < 5; i++ {
+		rewards = append(rewards, goftx.StakingReward{
+			ID:   int64(i + 1),
+			Coin: "SRM",
+			Size: dec("1.5"),
+			Time: start.Add(time.Duration(i+1) * 24 * time.Hour),
+		})
+	}
+	server, requests := newStakingServer(t, rewards)
+	client := goftx.New(goftx.WithBaseURL(server.URL), goftx.WithAuth("key", "secret"), goftx.WithRateLimiter(nil))
+
+	yields, err := client.GetRealizedYield(context.Background(), start, start.Add(10*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(yields) != 1 || !yields[0].Rewards.Equal(dec("7.5")) {
+		t.Fatalf("yields = %+v, want 7.5 SRM of rewards", yields)
+	}
+	if !yields[0].Yield.Equal(dec("0.0075")) {
+		t.Errorf("yield = %s, want 0.0075", yields[0].Yield)
+	}
+	if *requests < 3 {
+		t.Errorf("%d rewards requests, want every page of two", *requests)
+	}
+}
@@ -0,0 +1,586 @@
+package goftx
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+const (
+	wsPingPeriod             = 15 * time.Second
+	wsReconnectionCount      = 10
+	wsReconnectionInterval   = 10 * time.Second
+	wsInfoCodeServerRestarts = 20001
+
+	wsOpPing = "ping"
+	wsOpPong = "pong"
+
+	wsLoginPayload = "websocket_login"
+)
+
+type WsRequest struct {
+	Op      string                 `json:"op"`
+	Channel string                 `json:"channel,omitempty"`
+	Market  string                 `json:"market,omitempty"`
+	Args    map[string]interface{} `json:"args,omitempty"`
+}
+
+type WsResponse struct {
+	Channel string          `json:"channel"`
+	Market  string          `json:"market"`
+	Type    string          `json:"type"`
+	Code    int             `json:"code"`
+	Message string          `json:"msg"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type BaseResponse struct {
+	Type   string
+	Market string
+}
+
+type TickerResponse struct {
+	BaseResponse
+	Ticker
+}
+
+type TradesResponse struct {
+	BaseResponse
+	Trades []Trade
+}
+
+type OrderBookResponse struct {
+	BaseResponse
+	OrderBook
+}
+
+type FillResponse struct {
+	BaseResponse
+	Fill
+}
+
+type OrderResponse struct {
+	BaseResponse
+	Order
+}
+
+type MarketsResponse struct {
+	BaseResponse
+	Action  string            `json:"action"`
+	Markets map[string]Market `json:"data"`
+}
+
+type Stream struct {
+	client                 *Client
+	dialer                 *websocket.Dialer
+	wsReconnectionCount    int
+	wsReconnectionInterval time.Duration
+	errorHandler           func(error)
+}
+
+func (s *Stream) SetReconnectionCount(count int) {
+	s.wsReconnectionCount = count
+}
+
+func (s *Stream) SetReconnectionInterval(interval time.Duration) {
+	s.wsReconnectionInterval = interval
+}
+
+// SetErrorHandler registers a callback for errors that do not end a subscription:
+// error messages sent by the exchange, undecodable payloads and dropped connections.
+func (s *Stream) SetErrorHandler(handler func(error)) {
+	s.errorHandler = handler
+}
+
+func (s *Stream) SubscribeToTickers(ctx context.Context, markets ...string) (<-chan *TickerResponse, error) {
+	if len(markets) == 0 {
+		return nil, errors.New("markets not passed")
+	}
+
+	eventsC, err := s.serve(ctx, marketRequests(TickerChannel, markets)...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resultC := make(chan *TickerResponse)
+	go func() {
+		defer close(resultC)
+		for event := range eventsC {
+			var ticker Ticker
+			err := json.Unmarshal(event.Data, &ticker)
+			if err != nil {
+				s.handleError(errors.WithStack(err))
+				continue
+			}
+
+			select {
+			case resultC <- &TickerResponse{BaseResponse: baseResponse(event), Ticker: ticker}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return resultC, nil
+}
+
+func (s *Stream) SubscribeToTrades(ctx context.Context, markets ...string) (<-chan *TradesResponse, error) {
+	if len(markets) == 0 {
+		return nil, errors.New("markets not passed")
+	}
+
+	eventsC, err := s.serve(ctx, marketRequests(TradesChannel, markets)...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resultC := make(chan *TradesResponse)
+	go func() {
+		defer close(resultC)
+		for event := range eventsC {
+			var trades []Trade
+			err := json.Unmarshal(event.Data, &trades)
+			if err != nil {
+				s.handleError(errors.WithStack(err))
+				continue
+			}
+
+			select {
+			case resultC <- &TradesResponse{BaseResponse: baseResponse(event), Trades: trades}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return resultC, nil
+}
+
+func (s *Stream) SubscribeToOrderBooks(ctx context.Context, markets ...string) (<-chan *OrderBookResponse, error) {
+	if len(markets) == 0 {
+		return nil, errors.New("markets not passed")
+	}
+
+	eventsC, err := s.serve(ctx, marketRequests(OrderBookChannel, markets)...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resultC := make(chan *OrderBookResponse)
+	go func() {
+		defer close(resultC)
+		for event := range eventsC {
+			var orderBook OrderBook
+			err := json.Unmarshal(event.Data, &orderBook)
+			if err != nil {
+				s.handleError(errors.WithStack(err))
+				continue
+			}
+
+			select {
+			case resultC <- &OrderBookResponse{BaseResponse: baseResponse(event), OrderBook: orderBook}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return resultC, nil
+}
+
+func (s *Stream) SubscribeToMarkets(ctx context.Context) (<-chan *MarketsResponse, error) {
+	eventsC, err := s.serve(ctx, WsRequest{Op: Subscribe, Channel: MarketsChannel})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resultC := make(chan *MarketsResponse)
+	go func() {
+		defer close(resultC)
+		for event := range eventsC {
+			response := MarketsResponse{BaseResponse: baseResponse(event)}
+			err := json.Unmarshal(event.Data, &response)
+			if err != nil {
+				s.handleError(errors.WithStack(err))
+				continue
+			}
+
+			select {
+			case resultC <- &response:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return resultC, nil
+}
+
+func (s *Stream) SubscribeToFills(ctx context.Context) (<-chan *FillResponse, error) {
+	if s.client.apiKey == "" || s.client.secret == "" {
+		return nil, errors.New("api key and secret are required for private channels")
+	}
+
+	eventsC, err := s.serve(ctx, WsRequest{Op: Login}, WsRequest{Op: Subscribe, Channel: FillsChannel})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resultC := make(chan *FillResponse)
+	go func() {
+		defer close(resultC)
+		for event := range eventsC {
+			var fill Fill
+			err := json.Unmarshal(event.Data, &fill)
+			if err != nil {
+				s.handleError(errors.WithStack(err))
+				continue
+			}
+
+			select {
+			case resultC <- &FillResponse{BaseResponse: baseResponse(event), Fill: fill}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return resultC, nil
+}
+
+func (s *Stream) SubscribeToOrders(ctx context.Context) (<-chan *OrderResponse, error) {
+	if s.client.apiKey == "" || s.client.secret == "" {
+		return nil, errors.New("api key and secret are required for private channels")
+	}
+
+	eventsC, err := s.serve(ctx, WsRequest{Op: Login}, WsRequest{Op: Subscribe, Channel: OrdersChannel})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resultC := make(chan *OrderResponse)
+	go func() {
+		defer close(resultC)
+		for event := range eventsC {
+			var order Order
+			err := json.Unmarshal(event.Data, &order)
+			if err != nil {
+				s.handleError(errors.WithStack(err))
+				continue
+			}
+
+			select {
+			case resultC <- &OrderResponse{BaseResponse: baseResponse(event), Order: order}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return resultC, nil
+}
+
+// Connection is a websocket shared by subscriptions that are added and removed one channel and market
+// at a time. It reconnects like the Subscribe* channels and restores the current subscriptions.
+type Connection struct {
+	stream  *Stream
+	eventsC chan WsResponse
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	requests []WsRequest
+}
+
+// Connect dials a websocket without subscriptions, it is closed once ctx is done.
+// Partial and update messages of every subscription are sent on Events.
+func (s *Stream) Connect(ctx context.Context) (*Connection, error) {
+	c, err := s.open(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c, nil
+}
+
+// Events returns the messages of all subscriptions, Data holds the payload of the channel.
+// The channel is closed once the connection ends.
+func (c *Connection) Events() <-chan WsResponse {
+	return c.eventsC
+}
+
+// Subscribe adds channel for market, market is empty for the markets, fills and orders channels.
+// The connection is logged in before the first private channel. A subscription is kept and restored
+// on reconnection even when sending it fails.
+func (c *Connection) Subscribe(channel, market string) error {
+	private := channel == FillsChannel || channel == OrdersChannel
+	if private && (c.stream.client.apiKey == "" || c.stream.client.secret == "") {
+		return errors.New("api key and secret are required for private channels")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loggedIn := false
+	for _, req := range c.requests {
+		if req.Op == Login {
+			loggedIn = true
+		}
+		if req.Op == Subscribe && req.Channel == channel && req.Market == market {
+			return nil
+		}
+	}
+
+	var pending []WsRequest
+	if private && !loggedIn {
+		pending = append(pending, WsRequest{Op: Login})
+	}
+	pending = append(pending, WsRequest{Op: Subscribe, Channel: channel, Market: market})
+	c.requests = append(c.requests, pending...)
+
+	for _, req := range pending {
+		if req.Op == Login {
+			req = c.stream.loginRequest()
+		}
+		err := c.write(req)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Unsubscribe removes channel for market, the other subscriptions of the connection are not affected.
+func (c *Connection) Unsubscribe(channel, market string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, req := range c.requests {
+		if req.Op != Subscribe || req.Channel != channel || req.Market != market {
+			continue
+		}
+
+		c.requests = append(c.requests[:i:i], c.requests[i+1:]...)
+		req.Op = UnSubscribe
+		return errors.WithStack(c.write(req))
+	}
+
+	return errors.Errorf("not subscribed to channel %s market %s", channel, market)
+}
+
+// serve opens a connection for requests and returns its events.
+func (s *Stream) serve(ctx context.Context, requests ...WsRequest) (chan WsResponse, error) {
+	c, err := s.open(ctx, requests...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c.eventsC, nil
+}
+
+// open dials the websocket, sends the requests and forwards partial and update messages
+// until ctx is done. On a dropped connection or a server restart notice it reconnects and
+// replays the current requests. The events channel is closed once the connection ends.
+func (s *Stream) open(ctx context.Context, requests ...WsRequest) (*Connection, error) {
+	c := &Connection{
+		stream:   s,
+		eventsC:  make(chan WsResponse),
+		requests: requests,
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	go func() {
+		defer close(c.eventsC)
+		for {
+			err := c.read(ctx, conn)
+			if err == nil {
+				return
+			}
+			s.handleError(err)
+
+			conn, err = c.reconnect(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.handleError(err)
+				}
+				return
+			}
+		}
+	}()
+
+	return c, nil
+}
+
+// connect dials the websocket and sends the current requests, subscriptions wait until it is done.
+func (c *Connection) connect() (*websocket.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn = nil
+	conn, _, err := c.stream.dialer.Dial(c.stream.client.wsUrl, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, req := range c.requests {
+		// The login signature is only valid around its timestamp, so it is rebuilt on every (re)connect.
+		if req.Op == Login {
+			req = c.stream.loginRequest()
+		}
+
+		err = conn.WriteJSON(req)
+		if err != nil {
+			conn.Close()
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	c.conn = conn
+	return conn, nil
+}
+
+func (c *Connection) reconnect(ctx context.Context) (*websocket.Conn, error) {
+	for i := 0; i < c.stream.wsReconnectionCount; i++ {
+		select {
+		case <-ctx.Done():
+			return nil, errors.WithStack(ctx.Err())
+		case <-time.After(c.stream.wsReconnectionInterval):
+		}
+
+		conn, err := c.connect()
+		if err == nil {
+			return conn, nil
+		}
+		c.stream.handleError(err)
+	}
+
+	return nil, errors.Errorf("reconnection failed after %d attempts", c.stream.wsReconnectionCount)
+}
+
+// write sends req on the current websocket, callers hold c.mu. Without a websocket the
+// request is only replayed by the next reconnect.
+func (c *Connection) write(req WsRequest) error {
+	if c.conn == nil {
+		return nil
+	}
+	return errors.WithStack(c.conn.WriteJSON(req))
+}
+
+// read returns nil when ctx is done and the subscriptions were closed,
+// or an error when the connection has to be re-established.
+func (c *Connection) read(ctx context.Context, conn *websocket.Conn) error {
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		ticker := time.NewTicker(wsPingPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				c.mu.Lock()
+				for _, req := range c.requests {
+					if req.Op != Subscribe {
+						continue
+					}
+					req.Op = UnSubscribe
+					_ = conn.WriteJSON(req)
+				}
+				c.mu.Unlock()
+				_ = conn.Close()
+				return
+			case <-ticker.C:
+				c.mu.Lock()
+				err := conn.WriteJSON(WsRequest{Op: wsOpPing})
+				c.mu.Unlock()
+				if err != nil {
+					_ = conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		var msg WsResponse
+		err := conn.ReadJSON(&msg)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			conn.Close()
+			return errors.WithStack(err)
+		}
+
+		switch msg.Type {
+		case ResponseTypeSubscribed, ResponseTypeUnSubscribed, wsOpPong:
+			continue
+		case ResponseTypeError:
+			c.stream.handleError(errors.Errorf("Channel: %s	Market: %s	Code: %d	Error: %v", msg.Channel, msg.Market, msg.Code, msg.Message))
+			continue
+		case ResponseTypeInfo:
+			if msg.Code == wsInfoCodeServerRestarts {
+				conn.Close()
+				return errors.Errorf("Code: %d	Info: %v", msg.Code, msg.Message)
+			}
+			continue
+		}
+
+		select {
+		case c.eventsC <- msg:
+		case <-ctx.Done():
+			// The ping loop unsubscribes and closes the connection, which ends ReadJSON.
+		}
+	}
+}
+
+func (s *Stream) loginRequest() WsRequest {
+	ts := s.client.serverTime().Unix() * 1000
+	args := map[string]interface{}{
+		"key":  s.client.apiKey,
+		"sign": s.client.getSignature(strconv.FormatInt(ts, 10) + wsLoginPayload),
+		"time": ts,
+	}
+	if s.client.subAccount != "" {
+		// WithAuth stores the subaccount escaped for the REST header, the websocket expects the raw name.
+		subAccount, err := url.PathUnescape(s.client.subAccount)
+		if err != nil {
+			subAccount = s.client.subAccount
+		}
+		args["subaccount"] = subAccount
+	}
+
+	return WsRequest{
+		Op:   Login,
+		Args: args,
+	}
+}
+
+func (s *Stream) handleError(err error) {
+	if s.errorHandler != nil {
+		s.errorHandler(err)
+	}
+}
+
+func marketRequests(channel string, markets []string) []WsRequest {
+	requests := make([]WsRequest, 0, len(markets))
+	for _, market := range markets {
+		requests = append(requests, WsRequest{
+			Op:      Subscribe,
+			Channel: channel,
+			Market:  market,
+		})
+	}
+	return requests
+}
+
+func baseResponse(event WsResponse) BaseResponse {
+	return BaseResponse{
+		Type:   event.Type,
+		Market: event.Market,
+	}
+}
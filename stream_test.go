@@ -0,0 +1,194 @@
+package goftx_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/wizpacekorea/goftx"
+)
+
+// wsServer answers subscribe requests with a ticker message and records every request it receives.
+type wsServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []goftx.WsRequest
+	conns    []*websocket.Conn
+}
+
+func newWsServer(t *testing.T) *wsServer {
+	s := &wsServer{}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+
+		for {
+			var req goftx.WsRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.requests = append(s.requests, req)
+			s.mu.Unlock()
+
+			if req.Op == goftx.Subscribe {
+				_ = conn.WriteJSON(goftx.WsResponse{
+					Channel: req.Channel,
+					Market:  req.Market,
+					Type:    goftx.ResponseTypeUpdate,
+					Data:    json.RawMessage(`{"bid":1,"ask":2,"last":1.5,"time":1609459200.5}`),
+				})
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *wsServer) received() []goftx.WsRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]goftx.WsRequest(nil), s.requests...)
+}
+
+func (s *wsServer) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+func (s *wsServer) url() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func nextEvent(t *testing.T, events <-chan goftx.WsResponse) goftx.WsResponse {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+	return goftx.WsResponse{}
+}
+
+func TestConnectionSubscribeUnsubscribe(t *testing.T) {
+	server := newWsServer(t)
+	client := goftx.New(goftx.WithWebSocketURL(server.url()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn, err := client.Connect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, market := range []string{"BTC-PERP", "ETH-PERP"} {
+		err = conn.Subscribe(goftx.TickerChannel, market)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event := nextEvent(t, conn.Events()); event.Market != market {
+			t.Fatalf("event of %s, want %s", event.Market, market)
+		}
+	}
+
+	err = conn.Unsubscribe(goftx.TickerChannel, "ETH-PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Unsubscribe(goftx.TickerChannel, "ETH-PERP"); err == nil {
+		t.Error("unsubscribing twice succeeded")
+	}
+
+	// Subscriptions after an unsubscribe still share the connection.
+	err = conn.Subscribe(goftx.OrderBookChannel, "BTC-PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event := nextEvent(t, conn.Events()); event.Channel != goftx.OrderBookChannel {
+		t.Fatalf("event of channel %s, want %s", event.Channel, goftx.OrderBookChannel)
+	}
+
+	if n := server.connections(); n != 1 {
+		t.Errorf("%d connections, want 1", n)
+	}
+
+	want := []goftx.WsRequest{
+		{Op: goftx.Subscribe, Channel: goftx.TickerChannel, Market: "BTC-PERP"},
+		{Op: goftx.Subscribe, Channel: goftx.TickerChannel, Market: "ETH-PERP"},
+		{Op: goftx.UnSubscribe, Channel: goftx.TickerChannel, Market: "ETH-PERP"},
+		{Op: goftx.Subscribe, Channel: goftx.OrderBookChannel, Market: "BTC-PERP"},
+	}
+	got := server.received()
+	if len(got) != len(want) {
+		t.Fatalf("requests = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].Op != want[i].Op || got[i].Channel != want[i].Channel || got[i].Market != want[i].Market {
+			t.Fatalf("requests = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestConnectionRestoresSubscriptions(t *testing.T) {
+	server := newWsServer(t)
+	client := goftx.New(goftx.WithWebSocketURL(server.url()))
+	client.SetReconnectionInterval(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn, err := client.Connect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, market := range []string{"BTC-PERP", "ETH-PERP"} {
+		if err := conn.Subscribe(goftx.TradesChannel, market); err != nil {
+			t.Fatal(err)
+		}
+		nextEvent(t, conn.Events())
+	}
+	if err := conn.Unsubscribe(goftx.TradesChannel, "BTC-PERP"); err != nil {
+		t.Fatal(err)
+	}
+
+	server.mu.Lock()
+	_ = server.conns[0].Close()
+	server.mu.Unlock()
+
+	// Only the remaining subscription is replayed on the new connection.
+	if event := nextEvent(t, conn.Events()); event.Market != "ETH-PERP" {
+		t.Fatalf("event of %s after reconnect, want ETH-PERP", event.Market)
+	}
+	if n := server.connections(); n != 2 {
+		t.Errorf("%d connections, want 2", n)
+	}
+	got := server.received()
+	for _, req := range got[2:] {
+		if req.Op == goftx.Subscribe && req.Market == "BTC-PERP" {
+			t.Errorf("requests = %+v, BTC-PERP resubscribed after unsubscribing", got)
+		}
+	}
+	if last := got[len(got)-1]; last.Op != goftx.Subscribe || last.Market != "ETH-PERP" {
+		t.Errorf("requests = %+v, want ETH-PERP resubscribed last", got)
+	}
+}
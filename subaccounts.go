@@ -1,6 +1,7 @@
 package goftx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -51,10 +52,14 @@ type SubAccounts struct {
 }
 
 func (s *SubAccounts) GetSubAccounts() ([]SubAccount, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetSubAccountsWithContext(context.Background())
+}
+
+func (s *SubAccounts) GetSubAccountsWithContext(ctx context.Context) ([]SubAccount, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiSubAccounts),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiSubAccounts),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -75,6 +80,10 @@ func (s *SubAccounts) GetSubAccounts() ([]SubAccount, error) {
 }
 
 func (s *SubAccounts) CreateSubaccount(nickname string) (*SubAccount, error) {
+	return s.CreateSubaccountWithContext(context.Background(), nickname)
+}
+
+func (s *SubAccounts) CreateSubaccountWithContext(ctx context.Context, nickname string) (*SubAccount, error) {
 	body, err := json.Marshal(struct {
 		Nickname string `json:"nickname"`
 	}{Nickname: nickname})
@@ -82,10 +91,10 @@ func (s *SubAccounts) CreateSubaccount(nickname string) (*SubAccount, error) {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := s.client.prepareRequest(Request{
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiSubAccounts),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiSubAccounts),
 		Body:   body,
 	})
 	if err != nil {
@@ -107,6 +116,10 @@ func (s *SubAccounts) CreateSubaccount(nickname string) (*SubAccount, error) {
 }
 
 func (s *SubAccounts) ChangeSubaccount(nickname, newNickname string) error {
+	return s.ChangeSubaccountWithContext(context.Background(), nickname, newNickname)
+}
+
+func (s *SubAccounts) ChangeSubaccountWithContext(ctx context.Context, nickname, newNickname string) error {
 	body, err := json.Marshal(struct {
 		Nickname    string `json:"nickname"`
 		NewNickname string `json:"newNickname"`
@@ -115,10 +128,10 @@ func (s *SubAccounts) ChangeSubaccount(nickname, newNickname string) error {
 		return errors.WithStack(err)
 	}
 
-	request, err := s.client.prepareRequest(Request{
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiChangeSubAccountName),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiChangeSubAccountName),
 		Body:   body,
 	})
 	if err != nil {
@@ -134,6 +147,10 @@ func (s *SubAccounts) ChangeSubaccount(nickname, newNickname string) error {
 }
 
 func (s *SubAccounts) DeleteSubAccount(nickname string) error {
+	return s.DeleteSubAccountWithContext(context.Background(), nickname)
+}
+
+func (s *SubAccounts) DeleteSubAccountWithContext(ctx context.Context, nickname string) error {
 	body, err := json.Marshal(struct {
 		Nickname string `json:"nickname"`
 	}{Nickname: nickname})
@@ -141,10 +158,10 @@ func (s *SubAccounts) DeleteSubAccount(nickname string) error {
 		return errors.WithStack(err)
 	}
 
-	request, err := s.client.prepareRequest(Request{
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodDelete,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiSubAccounts),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiSubAccounts),
 		Body:   body,
 	})
 	if err != nil {
@@ -160,10 +177,14 @@ func (s *SubAccounts) DeleteSubAccount(nickname string) error {
 }
 
 func (s *SubAccounts) GetSubAccountBalances(nickname string) ([]Balance, error) {
-	request, err := s.client.prepareRequest(Request{
+	return s.GetSubAccountBalancesWithContext(context.Background(), nickname)
+}
+
+func (s *SubAccounts) GetSubAccountBalancesWithContext(ctx context.Context, nickname string) ([]Balance, error) {
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodGet,
-		URL:    fmt.Sprintf("%s%s", apiUrl, fmt.Sprintf(apiGetSubAccountBalances, nickname)),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, fmt.Sprintf(apiGetSubAccountBalances, nickname)),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -184,15 +205,19 @@ func (s *SubAccounts) GetSubAccountBalances(nickname string) ([]Balance, error)
 }
 
 func (s *SubAccounts) Transfer(payload *TransferPayload) (*TransferResponse, error) {
+	return s.TransferWithContext(context.Background(), payload)
+}
+
+func (s *SubAccounts) TransferWithContext(ctx context.Context, payload *TransferPayload) (*TransferResponse, error) {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	request, err := s.client.prepareRequest(Request{
+	request, err := s.client.prepareRequest(ctx, Request{
 		Auth:   true,
 		Method: http.MethodPost,
-		URL:    fmt.Sprintf("%s%s", apiUrl, apiTransfer),
+		URL:    fmt.Sprintf("%s%s", s.client.apiUrl, apiTransfer),
 		Body:   body,
 	})
 	if err != nil {
@@ -212,3 +237,21 @@ func (s *SubAccounts) Transfer(payload *TransferPayload) (*TransferResponse, err
 
 	return &result, nil
 }
+
+// ForEachSubAccount calls fn with a client view of every subaccount returned by GetSubAccounts,
+// it stops at the first error.
+func (s *SubAccounts) ForEachSubAccount(ctx context.Context, fn func(subAccount SubAccount, client *Client) error) error {
+	subAccounts, err := s.GetSubAccountsWithContext(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, subAccount := range subAccounts {
+		err = fn(subAccount, s.client.ForSubAccount(subAccount.Nickname))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
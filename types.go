@@ -42,7 +42,12 @@ const (
 	ResponseTypeUpdate       = "update"
 )
 
-const TransferStatusComplete = "complete"
+const (
+	TransferStatusRequested  = "requested"
+	TransferStatusProcessing = "processing"
+	TransferStatusComplete   = "complete"
+	TransferStatusCancelled  = "cancelled"
+)
 const (
 	OrderTypeLimitOrder  = "limit"
 	OrderTypeMarketOrder = "market"
@@ -59,6 +64,12 @@ const (
 	OrderStatusClosed = "closed"
 )
 
+const (
+	TriggerStatusOpen      = "open"
+	TriggerStatusCancelled = "cancelled"
+	TriggerStatusTriggered = "triggered"
+)
+
 const (
 	TriggerTypeStop         = "stop"
 	TriggerTypeTrailingStop = "trailingStop"
@@ -100,6 +111,11 @@ const (
 	LiquidityMaker = "maker"
 )
 
+const (
+	MarketTypeSpot   = "spot"
+	MarketTypeFuture = "future"
+)
+
 const (
 	FutureTypeFuture    = "future"
 	FutureTypePerpetual = "perpetual"
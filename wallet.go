@@ -0,0 +1,313 @@
+package goftx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	apiGetCoins          = "/wallet/coins"
+	apiGetDepositAddress = "/wallet/deposit_address/%s"
+	apiDeposits          = "/wallet/deposits"
+	apiWithdrawals       = "/wallet/withdrawals"
+	apiGetWithdrawalFee  = "/wallet/withdrawal_fee"
+)
+
+type Wallet struct {
+	client *Client
+}
+
+type Coin struct {
+	ID               string          `json:"id"`
+	Name             string          `json:"name"`
+	CanDeposit       bool            `json:"canDeposit"`
+	CanWithdraw      bool            `json:"canWithdraw"`
+	CanConvert       bool            `json:"canConvert"`
+	HasTag           bool            `json:"hasTag"`
+	Collateral       bool            `json:"collateral"`
+	CollateralWeight decimal.Decimal `json:"collateralWeight"`
+	Fiat             bool            `json:"fiat"`
+	IsToken          bool            `json:"isToken"`
+	UsdFungible      bool            `json:"usdFungible"`
+	CreditTo         string          `json:"creditTo"`
+	Methods          []string        `json:"methods"`
+	Erc20Contract    string          `json:"erc20Contract"`
+	Bep2Asset        string          `json:"bep2Asset"`
+	Trc20Contract    string          `json:"trc20Contract"`
+	SplMint          string          `json:"splMint"`
+}
+
+type DepositAddress struct {
+	Address string `json:"address"`
+	Tag     string `json:"tag"`
+	Method  string `json:"method"`
+	Coin    string `json:"coin"`
+}
+
+type GetDepositAddressParams struct {
+	Method *string `json:"method"`
+}
+
+type Deposit struct {
+	ID            int64           `json:"id"`
+	Coin          string          `json:"coin"`
+	Size          decimal.Decimal `json:"size"`
+	Fee           decimal.Decimal `json:"fee"`
+	Status        string          `json:"status"`
+	Confirmations int             `json:"confirmations"`
+	Time          time.Time       `json:"time"`
+	SentTime      time.Time       `json:"sentTime"`
+	ConfirmedTime time.Time       `json:"confirmedTime"`
+	TxID          string          `json:"txid"`
+	Address       *DepositAddress `json:"address"`
+	Notes         string          `json:"notes"`
+}
+
+type Withdrawal struct {
+	ID      int64           `json:"id"`
+	Coin    string          `json:"coin"`
+	Address string          `json:"address"`
+	Tag     string          `json:"tag"`
+	Method  string          `json:"method"`
+	Size    decimal.Decimal `json:"size"`
+	Fee     decimal.Decimal `json:"fee"`
+	Status  string          `json:"status"`
+	Time    time.Time       `json:"time"`
+	TxID    string          `json:"txid"`
+	Notes   string          `json:"notes"`
+}
+
+type GetWalletHistoryParams struct {
+	StartTime *int `json:"start_time"`
+	EndTime   *int `json:"end_time"`
+}
+
+type GetWithdrawalFeeParams struct {
+	Coin    string          `json:"coin"`
+	Size    decimal.Decimal `json:"size"`
+	Address string          `json:"address"`
+	Tag     *string         `json:"tag"`
+	Method  *string         `json:"method"`
+}
+
+type WithdrawalFee struct {
+	Method    string          `json:"method"`
+	Address   string          `json:"address"`
+	Fee       decimal.Decimal `json:"fee"`
+	Congested bool            `json:"congested"`
+}
+
+// Password is the withdrawal password and Code the 2FA code, both are only required when enabled on the account.
+type WithdrawPayload struct {
+	Coin     string          `json:"coin"`
+	Size     decimal.Decimal `json:"size"`
+	Address  string          `json:"address"`
+	Tag      *string         `json:"tag,omitempty"`
+	Method   *string         `json:"method,omitempty"`
+	Password *string         `json:"password,omitempty"`
+	Code     *string         `json:"code,omitempty"`
+}
+
+func (w *Wallet) GetCoins() ([]Coin, error) {
+	return w.GetCoinsWithContext(context.Background())
+}
+
+func (w *Wallet) GetCoinsWithContext(ctx context.Context) ([]Coin, error) {
+	request, err := w.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", w.client.apiUrl, apiGetCoins),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := w.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []Coin
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (w *Wallet) GetDepositAddress(coin string, params *GetDepositAddressParams) (*DepositAddress, error) {
+	return w.GetDepositAddressWithContext(context.Background(), coin, params)
+}
+
+func (w *Wallet) GetDepositAddressWithContext(ctx context.Context, coin string, params *GetDepositAddressParams) (*DepositAddress, error) {
+	queryParams, err := PrepareQueryParams(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := w.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", w.client.apiUrl, fmt.Sprintf(apiGetDepositAddress, coin)),
+		Params: queryParams,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := w.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *DepositAddress
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (w *Wallet) GetDepositHistory(params *GetWalletHistoryParams) ([]Deposit, error) {
+	return w.GetDepositHistoryWithContext(context.Background(), params)
+}
+
+func (w *Wallet) GetDepositHistoryWithContext(ctx context.Context, params *GetWalletHistoryParams) ([]Deposit, error) {
+	queryParams, err := PrepareQueryParams(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := w.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", w.client.apiUrl, apiDeposits),
+		Params: queryParams,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := w.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []Deposit
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (w *Wallet) GetWithdrawalHistory(params *GetWalletHistoryParams) ([]Withdrawal, error) {
+	return w.GetWithdrawalHistoryWithContext(context.Background(), params)
+}
+
+func (w *Wallet) GetWithdrawalHistoryWithContext(ctx context.Context, params *GetWalletHistoryParams) ([]Withdrawal, error) {
+	queryParams, err := PrepareQueryParams(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := w.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", w.client.apiUrl, apiWithdrawals),
+		Params: queryParams,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := w.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result []Withdrawal
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (w *Wallet) GetWithdrawalFee(params *GetWithdrawalFeeParams) (*WithdrawalFee, error) {
+	return w.GetWithdrawalFeeWithContext(context.Background(), params)
+}
+
+func (w *Wallet) GetWithdrawalFeeWithContext(ctx context.Context, params *GetWithdrawalFeeParams) (*WithdrawalFee, error) {
+	queryParams, err := PrepareQueryParams(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := w.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s%s", w.client.apiUrl, apiGetWithdrawalFee),
+		Params: queryParams,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := w.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *WithdrawalFee
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}
+
+func (w *Wallet) Withdraw(payload *WithdrawPayload) (*Withdrawal, error) {
+	return w.WithdrawWithContext(context.Background(), payload)
+}
+
+func (w *Wallet) WithdrawWithContext(ctx context.Context, payload *WithdrawPayload) (*Withdrawal, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request, err := w.client.prepareRequest(ctx, Request{
+		Auth:   true,
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s%s", w.client.apiUrl, apiWithdrawals),
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := w.client.do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var result *Withdrawal
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return result, nil
+}